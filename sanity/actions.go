@@ -0,0 +1,408 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ActionsService is a client for the Sanity Actions API.
+//
+// Actions operate on documents the way the studio does, for example by
+// publishing a draft, and are the recommended way to modify documents.
+//
+// Refer to https://www.sanity.io/docs/http-actions for more information.
+type ActionsService service
+
+const (
+	ActionTypeCreate            = "sanity.action.document.create"
+	ActionTypeDelete            = "sanity.action.document.delete"
+	ActionTypeDiscard           = "sanity.action.document.discard"
+	ActionTypeEdit              = "sanity.action.document.edit"
+	ActionTypePublish           = "sanity.action.document.publish"
+	ActionTypeUnpublish         = "sanity.action.document.unpublish"
+	ActionTypeReplaceDraft      = "sanity.action.document.replaceDraft"
+	ActionTypeCreateVersion     = "sanity.action.document.version.create"
+	ActionTypeDiscardVersion    = "sanity.action.document.version.discard"
+	ActionTypeReplaceVersion    = "sanity.action.document.version.replace"
+	ActionTypeUnpublishVersion  = "sanity.action.document.version.unpublish"
+	ActionTypeCreateRelease     = "sanity.action.release.create"
+	ActionTypePublishRelease    = "sanity.action.release.publish"
+	ActionTypeScheduleRelease   = "sanity.action.release.schedule"
+	ActionTypeUnscheduleRelease = "sanity.action.release.unschedule"
+	ActionTypeArchiveRelease    = "sanity.action.release.archive"
+	ActionTypeUnarchiveRelease  = "sanity.action.release.unarchive"
+	ActionTypeDeleteRelease     = "sanity.action.release.delete"
+)
+
+// An Action is an operation applied with the Actions API. The action types in
+// this package are named with an `Action` suffix, e.g., PublishAction.
+type Action interface {
+	// ActionType returns the value of the `actionType` attribute of the action.
+	// Valid values are represented as the `ActionType*` constants in this
+	// package.
+	ActionType() string
+}
+
+// marshalAction encodes `fields` as a JSON object with the `actionType`
+// attribute added.
+func marshalAction(actionType string, fields any) ([]byte, error) {
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf(`{"actionType":%q`, actionType)
+	if len(b) == 2 {
+		return []byte(prefix + "}"), nil
+	}
+
+	return append([]byte(prefix+","), b[1:]...), nil
+}
+
+// CreateAction creates a draft document.
+type CreateAction struct {
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+
+	// Attributes is the content of the draft document. Its `_id` must be the
+	// draft identifier, e.g., `drafts.<PublishedId>`.
+	Attributes any `json:"attributes"`
+
+	// IfExists describes what to do if the draft already exists. Valid values
+	// are `fail` and `ignore`.
+	IfExists string `json:"ifExists,omitempty"`
+}
+
+func (a CreateAction) ActionType() string { return ActionTypeCreate }
+
+func (a CreateAction) MarshalJSON() ([]byte, error) {
+	type action CreateAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// DeleteAction deletes a published document and its drafts.
+type DeleteAction struct {
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+
+	// IncludeDrafts are the identifiers of the drafts to delete along with the
+	// published document.
+	IncludeDrafts []string `json:"includeDrafts,omitempty"`
+}
+
+func (a DeleteAction) ActionType() string { return ActionTypeDelete }
+
+func (a DeleteAction) MarshalJSON() ([]byte, error) {
+	type action DeleteAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// DiscardAction deletes a draft document.
+type DiscardAction struct {
+	// DraftId is the identifier of the draft document.
+	DraftId string `json:"draftId"`
+
+	// Purge indicates whether the history of the draft is removed as well.
+	Purge bool `json:"purge,omitempty"`
+}
+
+func (a DiscardAction) ActionType() string { return ActionTypeDiscard }
+
+func (a DiscardAction) MarshalJSON() ([]byte, error) {
+	type action DiscardAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// EditAction patches a draft document, creating the draft from the published
+// document if it does not exist.
+type EditAction struct {
+	// DraftId is the identifier of the draft document.
+	DraftId string `json:"draftId"`
+
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+
+	// Patch describes the changes to the draft. Its Id and Query are ignored.
+	Patch *Patch `json:"patch"`
+}
+
+func (a EditAction) ActionType() string { return ActionTypeEdit }
+
+func (a EditAction) MarshalJSON() ([]byte, error) {
+	type action EditAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// PublishAction publishes a draft document.
+type PublishAction struct {
+	// DraftId is the identifier of the draft document.
+	DraftId string `json:"draftId"`
+
+	// IfDraftRevisionId makes the action fail unless the draft has the
+	// specified revision.
+	IfDraftRevisionId string `json:"ifDraftRevisionId,omitempty"`
+
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+
+	// IfPublishedRevisionId makes the action fail unless the published document
+	// has the specified revision.
+	IfPublishedRevisionId string `json:"ifPublishedRevisionId,omitempty"`
+}
+
+func (a PublishAction) ActionType() string { return ActionTypePublish }
+
+func (a PublishAction) MarshalJSON() ([]byte, error) {
+	type action PublishAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// UnpublishAction moves a published document back to a draft.
+type UnpublishAction struct {
+	// DraftId is the identifier of the draft document.
+	DraftId string `json:"draftId"`
+
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+}
+
+func (a UnpublishAction) ActionType() string { return ActionTypeUnpublish }
+
+func (a UnpublishAction) MarshalJSON() ([]byte, error) {
+	type action UnpublishAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// ReplaceDraftAction replaces the content of a draft document.
+type ReplaceDraftAction struct {
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+
+	// Attributes is the new content of the draft document.
+	Attributes any `json:"attributes"`
+}
+
+func (a ReplaceDraftAction) ActionType() string { return ActionTypeReplaceDraft }
+
+func (a ReplaceDraftAction) MarshalJSON() ([]byte, error) {
+	type action ReplaceDraftAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// CreateVersionAction creates a version document, either from the provided
+// content or by copying an existing document.
+type CreateVersionAction struct {
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+
+	// Document is the content of the version document. Its `_id` must be the
+	// version identifier.
+	Document any `json:"document,omitempty"`
+
+	// BaseId is the identifier of the document to copy. It is used instead of
+	// Document.
+	BaseId string `json:"baseId,omitempty"`
+
+	// VersionId is the identifier of the version document to create when
+	// copying from BaseId.
+	VersionId string `json:"versionId,omitempty"`
+
+	// IfBaseRevisionId makes the action fail unless the base document has the
+	// specified revision.
+	IfBaseRevisionId string `json:"ifBaseRevisionId,omitempty"`
+}
+
+func (a CreateVersionAction) ActionType() string { return ActionTypeCreateVersion }
+
+func (a CreateVersionAction) MarshalJSON() ([]byte, error) {
+	type action CreateVersionAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// DiscardVersionAction deletes a version document.
+type DiscardVersionAction struct {
+	// VersionId is the identifier of the version document.
+	VersionId string `json:"versionId"`
+
+	// Purge indicates whether the history of the version is removed as well.
+	Purge bool `json:"purge,omitempty"`
+}
+
+func (a DiscardVersionAction) ActionType() string { return ActionTypeDiscardVersion }
+
+func (a DiscardVersionAction) MarshalJSON() ([]byte, error) {
+	type action DiscardVersionAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// ReplaceVersionAction replaces the content of a version document.
+type ReplaceVersionAction struct {
+	// Document is the new content of the version document.
+	Document any `json:"document"`
+}
+
+func (a ReplaceVersionAction) ActionType() string { return ActionTypeReplaceVersion }
+
+func (a ReplaceVersionAction) MarshalJSON() ([]byte, error) {
+	type action ReplaceVersionAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// UnpublishVersionAction marks the published document to be unpublished when
+// the release containing the version is published.
+type UnpublishVersionAction struct {
+	// VersionId is the identifier of the version document.
+	VersionId string `json:"versionId"`
+
+	// PublishedId is the identifier of the published document.
+	PublishedId string `json:"publishedId"`
+}
+
+func (a UnpublishVersionAction) ActionType() string { return ActionTypeUnpublishVersion }
+
+func (a UnpublishVersionAction) MarshalJSON() ([]byte, error) {
+	type action UnpublishVersionAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// CreateReleaseAction creates a content release.
+type CreateReleaseAction struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string `json:"releaseId"`
+
+	// Metadata describes the release.
+	Metadata *ReleaseMetadata `json:"metadata,omitempty"`
+}
+
+func (a CreateReleaseAction) ActionType() string { return ActionTypeCreateRelease }
+
+func (a CreateReleaseAction) MarshalJSON() ([]byte, error) {
+	type action CreateReleaseAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// PublishReleaseAction publishes all the version documents of a release.
+type PublishReleaseAction struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string `json:"releaseId"`
+}
+
+func (a PublishReleaseAction) ActionType() string { return ActionTypePublishRelease }
+
+func (a PublishReleaseAction) MarshalJSON() ([]byte, error) {
+	type action PublishReleaseAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// ScheduleReleaseAction schedules a release to be published at a given time.
+type ScheduleReleaseAction struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string `json:"releaseId"`
+
+	// PublishAt is the time to publish the release.
+	PublishAt time.Time `json:"publishAt"`
+}
+
+func (a ScheduleReleaseAction) ActionType() string { return ActionTypeScheduleRelease }
+
+func (a ScheduleReleaseAction) MarshalJSON() ([]byte, error) {
+	type action ScheduleReleaseAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// UnscheduleReleaseAction cancels the scheduled publishing of a release.
+type UnscheduleReleaseAction struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string `json:"releaseId"`
+}
+
+func (a UnscheduleReleaseAction) ActionType() string { return ActionTypeUnscheduleRelease }
+
+func (a UnscheduleReleaseAction) MarshalJSON() ([]byte, error) {
+	type action UnscheduleReleaseAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// ArchiveReleaseAction archives a release that is no longer needed.
+type ArchiveReleaseAction struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string `json:"releaseId"`
+}
+
+func (a ArchiveReleaseAction) ActionType() string { return ActionTypeArchiveRelease }
+
+func (a ArchiveReleaseAction) MarshalJSON() ([]byte, error) {
+	type action ArchiveReleaseAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// UnarchiveReleaseAction restores an archived release.
+type UnarchiveReleaseAction struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string `json:"releaseId"`
+}
+
+func (a UnarchiveReleaseAction) ActionType() string { return ActionTypeUnarchiveRelease }
+
+func (a UnarchiveReleaseAction) MarshalJSON() ([]byte, error) {
+	type action UnarchiveReleaseAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// DeleteReleaseAction deletes an archived or published release.
+type DeleteReleaseAction struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string `json:"releaseId"`
+}
+
+func (a DeleteReleaseAction) ActionType() string { return ActionTypeDeleteRelease }
+
+func (a DeleteReleaseAction) MarshalJSON() ([]byte, error) {
+	type action DeleteReleaseAction
+	return marshalAction(a.ActionType(), action(a))
+}
+
+// ApplyActionsRequest is a transaction of actions that are applied atomically.
+type ApplyActionsRequest struct {
+	// Actions are the actions to apply, in order.
+	Actions []Action `json:"actions"`
+
+	// TransactionId is an optional identifier for the transaction. If left
+	// blank, an identifier is generated by Sanity, or by the client if retries
+	// are enabled.
+	TransactionId string `json:"transactionId,omitempty"`
+
+	// DryRun validates the actions without applying them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type ApplyActionsResponse struct {
+	// TransactionId is the identifier of the transaction.
+	TransactionId string `json:"transactionId"`
+}
+
+// Apply applies the actions in the request as a single transaction. Either all
+// the actions succeed or none of them are applied.
+func (s *ActionsService) Apply(ctx context.Context, projectId, dataset string, r *ApplyActionsRequest) (*ApplyActionsResponse, error) {
+	if len(r.Actions) == 0 {
+		return nil, errors.New("at least one action is required")
+	}
+
+	url := fmt.Sprintf("%s/data/actions/%s", s.client.projectBaseURL(projectId), dataset)
+
+	req := *r
+	transactionId, err := s.client.transactionId(req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	req.TransactionId = transactionId
+
+	var response ApplyActionsResponse
+	err = do(ctx, s.client.client, url, http.MethodPost, &req, &response)
+
+	return &response, err
+}
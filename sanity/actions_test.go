@@ -0,0 +1,79 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActionsService_Apply(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/data/actions/production" {
+			t.Errorf("Expected /data/actions/production path, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			Actions       []map[string]any `json:"actions"`
+			TransactionId string           `json:"transactionId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if len(body.Actions) != 2 {
+			t.Fatalf("Expected 2 actions, got %d", len(body.Actions))
+		}
+		if body.Actions[0]["actionType"] != ActionTypeEdit {
+			t.Errorf("Expected edit action, got %v", body.Actions[0])
+		}
+		if body.Actions[1]["actionType"] != ActionTypePublish || body.Actions[1]["draftId"] != "drafts.a" {
+			t.Errorf("Unexpected publish action %v", body.Actions[1])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	resp, err := client.Actions.Apply(context.Background(), "test-project", "production", &ApplyActionsRequest{
+		Actions: []Action{
+			EditAction{DraftId: "drafts.a", PublishedId: "a", Patch: &Patch{Set: map[string]any{"title": "Aliens"}}},
+			PublishAction{DraftId: "drafts.a", PublishedId: "a"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.TransactionId != "tx-1" {
+		t.Errorf("Expected transactionId 'tx-1', got '%s'", resp.TransactionId)
+	}
+}
+
+func TestActions_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		action   Action
+		expected string
+	}{
+		{DiscardAction{DraftId: "drafts.a"}, `{"actionType":"sanity.action.document.discard","draftId":"drafts.a"}`},
+		{&UnpublishAction{DraftId: "drafts.a", PublishedId: "a"}, `{"actionType":"sanity.action.document.unpublish","draftId":"drafts.a","publishedId":"a"}`},
+		{DiscardVersionAction{VersionId: "versions.r1.a", Purge: true}, `{"actionType":"sanity.action.document.version.discard","versionId":"versions.r1.a","purge":true}`},
+	}
+
+	for _, test := range tests {
+		b, err := json.Marshal(test.action)
+		if err != nil {
+			t.Fatalf("Failed to marshal %T: %v", test.action, err)
+		}
+		if string(b) != test.expected {
+			t.Errorf("Expected JSON %s, got %s", test.expected, b)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// AgentActionsService is a client for the Sanity Agent Actions API, which uses
+// AI to work with documents according to the deployed schema of a studio.
+//
+// Refer to https://www.sanity.io/docs/agent-actions for more information.
+type AgentActionsService service
+
+const (
+	// InstructionParamConstant is a literal string value.
+	InstructionParamConstant = "constant"
+
+	// InstructionParamField is the value of a field of the document.
+	InstructionParamField = "field"
+
+	// InstructionParamDocument is the whole document, or another document by
+	// its identifier.
+	InstructionParamDocument = "document"
+
+	// InstructionParamGROQ is the result of a GROQ query.
+	InstructionParamGROQ = "groq"
+)
+
+// An InstructionParam is a value substituted into an instruction. A parameter
+// `$name` in the instruction is supplied by the parameter with the key `name`.
+type InstructionParam struct {
+	// Type is the kind of value. Valid values are represented as the
+	// `InstructionParam*` constants in this package.
+	Type string `json:"type"`
+
+	// Value is the value of an InstructionParamConstant parameter.
+	Value string `json:"value,omitempty"`
+
+	// Path is the path to the field of an InstructionParamField parameter,
+	// e.g., `["author", "name"]`.
+	Path []string `json:"path,omitempty"`
+
+	// DocumentId is the identifier of the document of an
+	// InstructionParamDocument parameter. It defaults to the document of the
+	// action.
+	DocumentId string `json:"documentId,omitempty"`
+
+	// Query is the query of an InstructionParamGROQ parameter.
+	Query string `json:"query,omitempty"`
+
+	// Params are the values for the parameters referenced in the query of an
+	// InstructionParamGROQ parameter.
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// An AgentTarget selects the parts of a document an action works on.
+type AgentTarget struct {
+	// Path is the path to the field, e.g., `["body"]`. The whole document is
+	// targeted if it is empty.
+	Path []string `json:"path,omitempty"`
+
+	// Include limits the target to the child fields with the names.
+	Include []string `json:"include,omitempty"`
+
+	// Exclude removes the child fields with the names from the target.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// GenerateRequest describes content to generate for a document.
+type GenerateRequest struct {
+	// SchemaId is the identifier of the deployed schema of the studio, e.g.,
+	// `_.schemas.default`.
+	SchemaId string `json:"schemaId"`
+
+	// DocumentId is the identifier of the document to generate content for.
+	DocumentId string `json:"documentId"`
+
+	// Instruction describes the content to generate, e.g., `Write a summary of
+	// $body`.
+	Instruction string `json:"instruction"`
+
+	// InstructionParams are the values for the parameters referenced in the
+	// instruction.
+	InstructionParams map[string]InstructionParam `json:"instructionParams,omitempty"`
+
+	// Target selects the fields to generate. All fields of the document are
+	// generated if it is empty.
+	Target []AgentTarget `json:"target,omitempty"`
+
+	// NoWrite returns the generated document without saving it.
+	NoWrite bool `json:"noWrite,omitempty"`
+
+	// Async saves the generated content in the background and returns
+	// immediately, without the generated document.
+	Async bool `json:"async,omitempty"`
+}
+
+// Generate asks the AI to generate content for the fields of a document
+// according to the instruction, and returns the document with the generated
+// content. Unless NoWrite is set, the content is saved to the document.
+func (s *AgentActionsService) Generate(ctx context.Context, projectId, dataset string, r *GenerateRequest) (Document, error) {
+	if r.SchemaId == "" || r.DocumentId == "" || r.Instruction == "" {
+		return nil, errors.New("schema id, document id, and instruction are required")
+	}
+
+	url := fmt.Sprintf("%s/agent/action/generate/%s", s.client.projectBaseURL(projectId), dataset)
+
+	var doc Document
+	err := do(ctx, s.client.client, url, http.MethodPost, r, &doc)
+
+	return doc, err
+}
+
+const (
+	PromptFormatString = "string"
+	PromptFormatJSON   = "json"
+)
+
+// PromptRequest describes an instruction to run.
+type PromptRequest struct {
+	// Instruction is the instruction to run, e.g., `Suggest three titles for
+	// $doc`.
+	Instruction string `json:"instruction"`
+
+	// InstructionParams are the values for the parameters referenced in the
+	// instruction. Documents are provided as context with
+	// InstructionParamDocument parameters.
+	InstructionParams map[string]InstructionParam `json:"instructionParams,omitempty"`
+
+	// Format is the format of the response. Valid values are represented as
+	// the `PromptFormat*` constants in this package. Defaults to
+	// PromptFormatString. The instruction must ask for JSON when the format is
+	// PromptFormatJSON.
+	Format string `json:"format,omitempty"`
+
+	// Temperature controls the randomness of the response, from 0 to 1. Lower
+	// values give more predictable responses.
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+// Prompt runs the instruction and decodes the response into `result`. Unlike
+// Generate, Prompt does not change any documents.
+//
+// The `result` argument should be a `*string` for the PromptFormatString
+// format, or a pointer to a value that matches the shape of the requested JSON
+// for the PromptFormatJSON format.
+func (s *AgentActionsService) Prompt(ctx context.Context, projectId, dataset string, r *PromptRequest, result any) error {
+	if r.Instruction == "" {
+		return errors.New("instruction is required")
+	}
+
+	url := fmt.Sprintf("%s/agent/action/prompt/%s", s.client.projectBaseURL(projectId), dataset)
+
+	return do(ctx, s.client.client, url, http.MethodPost, r, result)
+}
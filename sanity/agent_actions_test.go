@@ -0,0 +1,110 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAgentActionsService_Generate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/agent/action/generate/production" {
+			t.Errorf("Expected /agent/action/generate/production path, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		params, _ := body["instructionParams"].(map[string]any)
+		bodyParam, _ := params["body"].(map[string]any)
+		target, _ := body["target"].([]any)
+		if body["schemaId"] != "_.schemas.default" || body["documentId"] != "post_1" || body["noWrite"] != true {
+			t.Errorf("Unexpected body %v", body)
+		}
+		if bodyParam["type"] != InstructionParamField || len(bodyParam["path"].([]any)) != 1 {
+			t.Errorf("Unexpected instruction params %v", params)
+		}
+		if len(target) != 1 || target[0].(map[string]any)["path"].([]any)[0] != "summary" {
+			t.Errorf("Unexpected target %v", body["target"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"post_1","_type":"post","summary":"A short summary."}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	doc, err := client.AgentActions.Generate(context.Background(), "test-project", "production", &GenerateRequest{
+		SchemaId:    "_.schemas.default",
+		DocumentId:  "post_1",
+		Instruction: "Summarize $body in one sentence",
+		InstructionParams: map[string]InstructionParam{
+			"body": {Type: InstructionParamField, Path: []string{"body"}},
+		},
+		Target:  []AgentTarget{{Path: []string{"summary"}}},
+		NoWrite: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if doc["summary"] != "A short summary." {
+		t.Errorf("Unexpected document %v", doc)
+	}
+}
+
+func TestAgentActionsService_Generate_MissingInstruction(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.AgentActions.Generate(context.Background(), "test-project", "production", &GenerateRequest{SchemaId: "_.schemas.default", DocumentId: "post_1"})
+	if err == nil {
+		t.Error("Expected an error for a missing instruction")
+	}
+}
+
+func TestAgentActionsService_Prompt(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/agent/action/prompt/production" {
+			t.Errorf("Expected /agent/action/prompt/production path, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["format"] != PromptFormatJSON || body["temperature"] != float64(0) {
+			t.Errorf("Unexpected body %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"titles":["One","Two","Three"]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	temperature := 0.0
+	var result struct {
+		Titles []string `json:"titles"`
+	}
+	err := client.AgentActions.Prompt(context.Background(), "test-project", "production", &PromptRequest{
+		Instruction: `Suggest three titles for $doc as {"titles": [...]}`,
+		InstructionParams: map[string]InstructionParam{
+			"doc": {Type: InstructionParamDocument, DocumentId: "post_1"},
+		},
+		Format:      PromptFormatJSON,
+		Temperature: &temperature,
+	}, &result)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Titles) != 3 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
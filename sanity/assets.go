@@ -0,0 +1,514 @@
+package sanity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AssetsService is a client for the Sanity Assets API.
+//
+// Refer to https://www.sanity.io/docs/http-api-assets for more information.
+type AssetsService service
+
+const (
+	assetTypeImage = "image"
+	assetTypeFile  = "file"
+)
+
+// An Asset is a document describing a binary asset stored in a dataset.
+type Asset struct {
+	// Id is the unique identifier for the asset document.
+	Id string `json:"_id"`
+
+	// Type is the document type, either `sanity.imageAsset` or
+	// `sanity.fileAsset`.
+	Type string `json:"_type"`
+
+	// Rev is the revision of the asset document.
+	Rev string `json:"_rev,omitempty"`
+
+	// CreatedAt is the time the asset was uploaded.
+	CreatedAt time.Time `json:"_createdAt"`
+
+	// UpdatedAt is the time the asset document was last updated.
+	UpdatedAt time.Time `json:"_updatedAt"`
+
+	// AssetId is the content-derived identifier of the asset.
+	AssetId string `json:"assetId"`
+
+	// OriginalFilename is the filename of the asset when it was uploaded.
+	OriginalFilename string `json:"originalFilename,omitempty"`
+
+	// MimeType is the MIME type of the asset, e.g., `image/png`.
+	MimeType string `json:"mimeType"`
+
+	// Extension is the file extension of the asset, e.g., `png`.
+	Extension string `json:"extension"`
+
+	// Size is the size of the asset in bytes.
+	Size int64 `json:"size"`
+
+	// Path is the path of the asset on the Sanity CDN.
+	Path string `json:"path"`
+
+	// URL is the full URL of the asset on the Sanity CDN.
+	URL string `json:"url"`
+
+	// Label is a short descriptive label for the asset.
+	Label string `json:"label,omitempty"`
+
+	// Title is the display-friendly title of the asset.
+	Title string `json:"title,omitempty"`
+
+	// Description is a longer description of the asset.
+	Description string `json:"description,omitempty"`
+
+	// Sha1Hash is the SHA-1 hash of the content of the asset.
+	Sha1Hash string `json:"sha1hash"`
+
+	// Source describes the system the asset originates from, if any.
+	Source *AssetSource `json:"source,omitempty"`
+}
+
+// An AssetSource describes the system an asset originates from.
+type AssetSource struct {
+	// Name is the name of the originating system, e.g., `unsplash`.
+	Name string `json:"name"`
+
+	// Id is the identifier of the asset in the originating system.
+	Id string `json:"id"`
+
+	// URL is the URL of the asset in the originating system.
+	URL string `json:"url,omitempty"`
+}
+
+// An ImageAsset is an asset document of type `sanity.imageAsset`.
+type ImageAsset struct {
+	Asset
+
+	// Metadata is the metadata extracted from the image when it was uploaded.
+	Metadata ImageMetadata `json:"metadata"`
+}
+
+// A FileAsset is an asset document of type `sanity.fileAsset`.
+type FileAsset struct {
+	Asset
+}
+
+// ImageMetadata is the metadata extracted from an image when it is uploaded.
+type ImageMetadata struct {
+	// Dimensions are the dimensions of the image.
+	Dimensions ImageDimensions `json:"dimensions"`
+
+	// Palette describes the dominant colors of the image.
+	Palette *ImagePalette `json:"palette,omitempty"`
+
+	// Exif contains the EXIF data of the image, if extracted.
+	Exif map[string]any `json:"exif,omitempty"`
+
+	// Location is the location the image was taken, if extracted.
+	Location *GeoPoint `json:"location,omitempty"`
+
+	// Lqip is a low-quality image placeholder encoded as a data URL.
+	Lqip string `json:"lqip,omitempty"`
+
+	// BlurHash is a compact placeholder representation of the image.
+	BlurHash string `json:"blurHash,omitempty"`
+
+	// HasAlpha indicates whether the image has an alpha channel.
+	HasAlpha bool `json:"hasAlpha"`
+
+	// IsOpaque indicates whether the image is fully opaque.
+	IsOpaque bool `json:"isOpaque"`
+}
+
+// ImageDimensions are the dimensions of an image in pixels.
+type ImageDimensions struct {
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	AspectRatio float64 `json:"aspectRatio"`
+}
+
+// An ImagePalette describes the dominant colors of an image.
+type ImagePalette struct {
+	Dominant     *ImagePaletteSwatch `json:"dominant,omitempty"`
+	Vibrant      *ImagePaletteSwatch `json:"vibrant,omitempty"`
+	LightVibrant *ImagePaletteSwatch `json:"lightVibrant,omitempty"`
+	DarkVibrant  *ImagePaletteSwatch `json:"darkVibrant,omitempty"`
+	Muted        *ImagePaletteSwatch `json:"muted,omitempty"`
+	LightMuted   *ImagePaletteSwatch `json:"lightMuted,omitempty"`
+	DarkMuted    *ImagePaletteSwatch `json:"darkMuted,omitempty"`
+}
+
+// An ImagePaletteSwatch is a color in an image palette along with colors that
+// are legible on top of it.
+type ImagePaletteSwatch struct {
+	Background string  `json:"background"`
+	Foreground string  `json:"foreground"`
+	Title      string  `json:"title"`
+	Population float64 `json:"population"`
+}
+
+// A GeoPoint is a geographic location.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+	Alt float64 `json:"alt,omitempty"`
+}
+
+// ListAssetsRequest describes filters for listing assets.
+type ListAssetsRequest struct {
+	// MimeType filters the assets by MIME type. A trailing `*` matches any
+	// MIME type with the preceding prefix, e.g., `image/*`.
+	MimeType string
+
+	// MinSize is the minimum size in bytes of the assets.
+	MinSize int64
+
+	// MaxSize is the maximum size in bytes of the assets.
+	MaxSize int64
+
+	// UploadedAfter filters the assets to those uploaded after the time.
+	UploadedAfter time.Time
+
+	// UploadedBefore filters the assets to those uploaded before the time.
+	UploadedBefore time.Time
+}
+
+// query returns the GROQ query and parameters that select the assets of the
+// specified type.
+func (r *ListAssetsRequest) query(assetType string) (string, map[string]any) {
+	params := map[string]any{"type": assetDocumentType(assetType)}
+	filters := []string{"_type == $type"}
+
+	if prefix := strings.TrimSuffix(r.MimeType, "*"); prefix != r.MimeType {
+		filters = append(filters, "string::startsWith(mimeType, $mimeType)")
+		params["mimeType"] = prefix
+	} else if r.MimeType != "" {
+		filters = append(filters, "mimeType == $mimeType")
+		params["mimeType"] = r.MimeType
+	}
+	if r.MinSize > 0 {
+		filters = append(filters, "size >= $minSize")
+		params["minSize"] = r.MinSize
+	}
+	if r.MaxSize > 0 {
+		filters = append(filters, "size <= $maxSize")
+		params["maxSize"] = r.MaxSize
+	}
+	if !r.UploadedAfter.IsZero() {
+		filters = append(filters, "_createdAt > $uploadedAfter")
+		params["uploadedAfter"] = r.UploadedAfter.UTC().Format(time.RFC3339)
+	}
+	if !r.UploadedBefore.IsZero() {
+		filters = append(filters, "_createdAt < $uploadedBefore")
+		params["uploadedBefore"] = r.UploadedBefore.UTC().Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("*[%s] | order(_createdAt desc)", strings.Join(filters, " && ")), params
+}
+
+// assetDocumentType returns the document type of assets of the specified type.
+func assetDocumentType(assetType string) string {
+	return "sanity." + assetType + "Asset"
+}
+
+// ListImages fetches and returns the image assets in the dataset that match
+// the filters, most recently uploaded first.
+func (s *AssetsService) ListImages(ctx context.Context, projectId, dataset string, r *ListAssetsRequest) ([]ImageAsset, error) {
+	query, params := r.query(assetTypeImage)
+
+	var assets []ImageAsset
+	err := s.client.Documents.Query(ctx, projectId, dataset, &QueryRequest{Query: query, Params: params}, &assets)
+
+	return assets, err
+}
+
+// ListFiles fetches and returns the file assets in the dataset that match the
+// filters, most recently uploaded first.
+func (s *AssetsService) ListFiles(ctx context.Context, projectId, dataset string, r *ListAssetsRequest) ([]FileAsset, error) {
+	query, params := r.query(assetTypeFile)
+
+	var assets []FileAsset
+	err := s.client.Documents.Query(ctx, projectId, dataset, &QueryRequest{Query: query, Params: params}, &assets)
+
+	return assets, err
+}
+
+// FindReferences fetches and returns all documents that reference the
+// specified asset document. An asset with no references is orphaned and may be
+// safely deleted.
+func (s *AssetsService) FindReferences(ctx context.Context, projectId, dataset, assetId string) ([]Document, error) {
+	var docs []Document
+	err := s.client.Documents.Query(ctx, projectId, dataset, &QueryRequest{
+		Query:  "*[references($assetId)]",
+		Params: map[string]any{"assetId": assetId},
+	}, &docs)
+
+	return docs, err
+}
+
+// UploadAssetRequest describes a binary asset to upload.
+type UploadAssetRequest struct {
+	// Body is the content of the asset. It is streamed to the API without being
+	// buffered in memory.
+	Body io.Reader
+
+	// Size is the length of Body in bytes, if known. It is used as the total
+	// reported to OnProgress.
+	Size int64
+
+	// ContentType is the MIME type of the asset, e.g., `image/png`.
+	ContentType string
+
+	// Filename is the original filename of the asset.
+	Filename string
+
+	// Label is a short descriptive label for the asset.
+	Label string
+
+	// Title is the display-friendly title of the asset.
+	Title string
+
+	// Description is a longer description of the asset.
+	Description string
+
+	// SourceName is the name of the system the asset originates from, e.g.,
+	// `unsplash`. It is stored in the `source` attribute of the asset document.
+	SourceName string
+
+	// SourceId is the identifier of the asset in the originating system.
+	SourceId string
+
+	// SourceURL is the URL of the asset in the originating system.
+	SourceURL string
+
+	// OnProgress is called as the body is sent with the number of bytes sent so
+	// far and the total size of the body. The total is zero if Size is unknown.
+	OnProgress func(sent, total int64)
+}
+
+// UploadImage streams an image to the dataset and returns the resulting asset
+// document.
+//
+// The Assets API accepts an asset in a single request and does not support
+// chunked or resumable uploads, so a failed upload must be restarted from the
+// beginning. To make restarting safe after a failure or a process restart, use
+// UploadImageIfMissing, which skips assets that were already uploaded.
+func (s *AssetsService) UploadImage(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*ImageAsset, error) {
+	var asset ImageAsset
+	err := s.upload(ctx, projectId, dataset, assetTypeImage, r, &asset)
+
+	return &asset, err
+}
+
+// UploadFile streams a file to the dataset and returns the resulting asset
+// document.
+//
+// As with UploadImage, a failed upload must be restarted from the beginning.
+// Use UploadFileIfMissing to make restarting safe.
+func (s *AssetsService) UploadFile(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*FileAsset, error) {
+	var asset FileAsset
+	err := s.upload(ctx, projectId, dataset, assetTypeFile, r, &asset)
+
+	return &asset, err
+}
+
+func (s *AssetsService) upload(ctx context.Context, projectId, dataset, assetType string, r *UploadAssetRequest, result any) error {
+	if r.Body == nil {
+		return errors.New("body is required")
+	}
+
+	params := url.Values{}
+	if r.Filename != "" {
+		params.Set("filename", r.Filename)
+	}
+	if r.Label != "" {
+		params.Set("label", r.Label)
+	}
+	if r.Title != "" {
+		params.Set("title", r.Title)
+	}
+	if r.Description != "" {
+		params.Set("description", r.Description)
+	}
+	if r.SourceName != "" {
+		params.Set("sourceName", r.SourceName)
+	}
+	if r.SourceId != "" {
+		params.Set("sourceId", r.SourceId)
+	}
+	if r.SourceURL != "" {
+		params.Set("sourceUrl", r.SourceURL)
+	}
+
+	url := fmt.Sprintf("%s/assets/%ss/%s", s.client.projectBaseURL(projectId), assetType, dataset)
+	if len(params) > 0 {
+		url += "?" + params.Encode()
+	}
+
+	var body io.Reader = r.Body
+	if r.OnProgress != nil {
+		body = &progressReader{reader: r.Body, total: r.Size, onProgress: r.OnProgress}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	if r.Size > 0 {
+		req.ContentLength = r.Size
+	}
+	if r.ContentType != "" {
+		req.Header.Set("Content-Type", r.ContentType)
+	}
+
+	type response struct {
+		Document any `json:"document"`
+	}
+
+	return doRequest(s.client.client, req, &response{Document: result})
+}
+
+// SourceNameSHA256 is the source name recorded by the `Upload*IfMissing`
+// functions for assets that are identified by the SHA-256 hash of their
+// content.
+const SourceNameSHA256 = "sha256"
+
+// UploadImageIfMissing uploads an image unless an image from the same source
+// already exists in the dataset, and reports whether a new asset was created.
+//
+// Assets are identified by SourceName and SourceId, which must be set
+// together. If both are blank, the asset is identified by the SHA-256 hash of
+// its content instead, which is recorded as the source of the uploaded asset.
+// In that case Body must implement io.ReadSeeker so that it can be hashed
+// before it is uploaded.
+//
+// This makes it safe to re-run an import without creating duplicate assets.
+func (s *AssetsService) UploadImageIfMissing(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*ImageAsset, bool, error) {
+	var asset ImageAsset
+	created, err := s.uploadIfMissing(ctx, projectId, dataset, assetTypeImage, r, &asset)
+
+	return &asset, created, err
+}
+
+// UploadFileIfMissing uploads a file unless a file from the same source
+// already exists in the dataset, and reports whether a new asset was created.
+//
+// Assets are identified in the same manner as UploadImageIfMissing.
+func (s *AssetsService) UploadFileIfMissing(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*FileAsset, bool, error) {
+	var asset FileAsset
+	created, err := s.uploadIfMissing(ctx, projectId, dataset, assetTypeFile, r, &asset)
+
+	return &asset, created, err
+}
+
+func (s *AssetsService) uploadIfMissing(ctx context.Context, projectId, dataset, assetType string, r *UploadAssetRequest, result any) (bool, error) {
+	req := *r
+	var v validation
+	v.check(req.SourceName != "" || req.SourceId == "", "source name", req.SourceName,
+		"source name is required when a source id is given")
+	v.check(req.SourceId != "" || req.SourceName == "", "source id", req.SourceId,
+		"source id is required when a source name is given")
+	if err := v.err(); err != nil {
+		return false, err
+	}
+	if req.SourceName == "" {
+		body, ok := r.Body.(io.ReadSeeker)
+		if !ok {
+			return false, errors.New("body must implement io.ReadSeeker when no source is given")
+		}
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, body); err != nil {
+			return false, err
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+
+		req.SourceName = SourceNameSHA256
+		req.SourceId = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	var existing json.RawMessage
+	err := s.client.Documents.Query(ctx, projectId, dataset, &QueryRequest{
+		Query: "*[_type == $type && source.name == $sourceName && source.id == $sourceId][0]",
+		Params: map[string]any{
+			"type":       assetDocumentType(assetType),
+			"sourceName": req.SourceName,
+			"sourceId":   req.SourceId,
+		},
+	}, &existing)
+	if err != nil {
+		return false, err
+	}
+	if string(existing) != "null" {
+		return false, json.Unmarshal(existing, result)
+	}
+
+	err = s.upload(ctx, projectId, dataset, assetType, &req, result)
+
+	return err == nil, err
+}
+
+// LinkMediaLibraryAssetRequest identifies a Media Library asset to link into
+// a dataset.
+type LinkMediaLibraryAssetRequest struct {
+	// MediaLibraryId is the identifier of the media library.
+	MediaLibraryId string `json:"mediaLibraryId"`
+
+	// AssetId is the identifier of the asset in the media library.
+	AssetId string `json:"assetId"`
+
+	// AssetInstanceId is the identifier of the version of the asset to link.
+	AssetInstanceId string `json:"assetInstanceId"`
+}
+
+// LinkMediaLibraryAsset makes an asset of a Media Library available in the
+// dataset by creating a local asset document that is linked to it. The
+// returned asset can be referenced by documents in the same way as an
+// uploaded asset, without uploading the file again.
+func (s *AssetsService) LinkMediaLibraryAsset(ctx context.Context, projectId, dataset string, r *LinkMediaLibraryAssetRequest) (*Asset, error) {
+	if r.MediaLibraryId == "" || r.AssetId == "" || r.AssetInstanceId == "" {
+		return nil, errors.New("media library id, asset id, and asset instance id are required")
+	}
+
+	url := fmt.Sprintf("%s/assets/media-library-link/%s", s.client.projectBaseURL(projectId), dataset)
+
+	type response struct {
+		Document Asset `json:"document"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodPost, r, &resp)
+
+	return &resp.Document, err
+}
+
+// progressReader reports the number of bytes read from the underlying reader.
+type progressReader struct {
+	reader     io.Reader
+	sent       int64
+	total      int64
+	onProgress func(sent, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.onProgress(r.sent, r.total)
+	}
+
+	return n, err
+}
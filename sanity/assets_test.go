@@ -0,0 +1,270 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssetsService_Upload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/assets/images/production" {
+			t.Errorf("Expected /assets/images/production path, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("filename") != "logo.png" {
+			t.Errorf("Expected filename 'logo.png', got %s", r.URL.RawQuery)
+		}
+		if r.Header.Get("Content-Type") != "image/png" {
+			t.Errorf("Expected Content-Type 'image/png', got '%s'", r.Header.Get("Content-Type"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "not really a png" {
+			t.Errorf("Unexpected body '%s'", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"document":{"_id":"image-abc-1x1-png","_type":"sanity.imageAsset","originalFilename":"logo.png","metadata":{"dimensions":{"width":1,"height":1,"aspectRatio":1},"hasAlpha":true}}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var lastSent, lastTotal int64
+	asset, err := client.Assets.UploadImage(context.Background(), "test-project", "production", &UploadAssetRequest{
+		Body:        strings.NewReader("not really a png"),
+		Size:        16,
+		ContentType: "image/png",
+		Filename:    "logo.png",
+		OnProgress: func(sent, total int64) {
+			lastSent, lastTotal = sent, total
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if asset.Id != "image-abc-1x1-png" {
+		t.Errorf("Expected asset ID 'image-abc-1x1-png', got '%s'", asset.Id)
+	}
+	if asset.OriginalFilename != "logo.png" {
+		t.Errorf("Expected original filename 'logo.png', got '%s'", asset.OriginalFilename)
+	}
+	if asset.Metadata.Dimensions.Width != 1 || !asset.Metadata.HasAlpha {
+		t.Errorf("Unexpected metadata %+v", asset.Metadata)
+	}
+	if lastSent != 16 || lastTotal != 16 {
+		t.Errorf("Expected final progress 16/16, got %d/%d", lastSent, lastTotal)
+	}
+}
+
+func TestAssetsService_Upload_MissingBody(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.Assets.UploadFile(context.Background(), "test-project", "production", &UploadAssetRequest{})
+	if err == nil {
+		t.Error("Expected an error for a missing body")
+	}
+}
+
+func TestAssetsService_ListImages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/query/production" {
+			t.Errorf("Expected /data/query/production path, got %s", r.URL.Path)
+		}
+
+		var req QueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		expectedQuery := `*[_type == $type && string::startsWith(mimeType, $mimeType) && size <= $maxSize && _createdAt > $uploadedAfter] | order(_createdAt desc)`
+		if req.Query != expectedQuery {
+			t.Errorf("Expected query '%s', got '%s'", expectedQuery, req.Query)
+		}
+		if req.Params["type"] != "sanity.imageAsset" || req.Params["mimeType"] != "image/" || req.Params["uploadedAfter"] != "2024-01-02T00:00:00Z" {
+			t.Errorf("Unexpected params %v", req.Params)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"_id":"image-abc-1x1-png","_type":"sanity.imageAsset","mimeType":"image/png","size":512,"_createdAt":"2024-03-01T10:00:00Z"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	assets, err := client.Assets.ListImages(context.Background(), "test-project", "production", &ListAssetsRequest{
+		MimeType:      "image/*",
+		MaxSize:       1024,
+		UploadedAfter: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(assets) != 1 {
+		t.Fatalf("Expected 1 asset, got %d", len(assets))
+	}
+	if assets[0].MimeType != "image/png" || assets[0].Size != 512 {
+		t.Errorf("Unexpected asset %+v", assets[0])
+	}
+}
+
+func TestAssetsService_UploadIfMissing(t *testing.T) {
+	const hash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" // sha256("test")
+
+	uploads := 0
+	existing := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/data/query/production":
+			var req QueryRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Params["sourceName"] != SourceNameSHA256 || req.Params["sourceId"] != hash {
+				t.Errorf("Unexpected params %v", req.Params)
+			}
+			if existing {
+				w.Write([]byte(`{"result":{"_id":"file-abc-txt","_type":"sanity.fileAsset"}}`))
+			} else {
+				w.Write([]byte(`{"result":null}`))
+			}
+		case "/assets/files/production":
+			uploads++
+			if r.URL.Query().Get("sourceId") != hash {
+				t.Errorf("Expected sourceId to be the content hash, got %s", r.URL.RawQuery)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != "test" {
+				t.Errorf("Expected the full body to be uploaded, got '%s'", body)
+			}
+			existing = true
+			w.Write([]byte(`{"document":{"_id":"file-abc-txt","_type":"sanity.fileAsset"}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	for i, expectCreated := range []bool{true, false} {
+		asset, created, err := client.Assets.UploadFileIfMissing(context.Background(), "test-project", "production", &UploadAssetRequest{
+			Body: strings.NewReader("test"),
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if created != expectCreated {
+			t.Errorf("Upload %d: expected created to be %v, got %v", i, expectCreated, created)
+		}
+		if asset.Id != "file-abc-txt" {
+			t.Errorf("Upload %d: expected asset ID 'file-abc-txt', got '%s'", i, asset.Id)
+		}
+	}
+
+	if uploads != 1 {
+		t.Errorf("Expected 1 upload, got %d", uploads)
+	}
+}
+
+func TestAssetsService_UploadIfMissing_PartialSource(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	for _, r := range []*UploadAssetRequest{
+		{Body: strings.NewReader("test"), SourceName: "legacy-cms"},
+		{Body: strings.NewReader("test"), SourceId: "asset-1"},
+	} {
+		_, _, err := client.Assets.UploadFileIfMissing(context.Background(), "test-project", "production", r)
+		var validationErrs ValidationErrors
+		if !errors.As(err, &validationErrs) || len(validationErrs) != 1 {
+			t.Errorf("Expected ValidationErrors for %+v, got %v", r, err)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("Expected no requests, got %d", requests)
+	}
+}
+
+func TestAssetsService_FindReferences(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Query != "*[references($assetId)]" || req.Params["assetId"] != "image-abc-1x1-png" {
+			t.Errorf("Unexpected query '%s' with params %v", req.Query, req.Params)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"_id":"movie_1","_type":"movie"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	docs, err := client.Assets.FindReferences(context.Background(), "test-project", "production", "image-abc-1x1-png")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(docs) != 1 || docs[0].Id() != "movie_1" {
+		t.Errorf("Expected a single reference 'movie_1', got %v", docs)
+	}
+}
+
+func TestAssetsService_LinkMediaLibraryAsset(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/assets/media-library-link/production" {
+			t.Errorf("Expected /assets/media-library-link/production path, got %s", r.URL.Path)
+		}
+
+		var body LinkMediaLibraryAssetRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.MediaLibraryId != "ml-1" || body.AssetId != "asset-1" || body.AssetInstanceId != "instance-1" {
+			t.Errorf("Unexpected body %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"document":{"_id":"image-abc-1x1-png","_type":"sanity.imageAsset","mimeType":"image/png"}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	asset, err := client.Assets.LinkMediaLibraryAsset(context.Background(), "test-project", "production", &LinkMediaLibraryAssetRequest{
+		MediaLibraryId:  "ml-1",
+		AssetId:         "asset-1",
+		AssetInstanceId: "instance-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if asset.Id != "image-abc-1x1-png" || asset.Type != "sanity.imageAsset" {
+		t.Errorf("Unexpected asset %+v", asset)
+	}
+}
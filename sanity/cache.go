@@ -0,0 +1,189 @@
+package sanity
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// A Cache stores the responses of GET requests by URL, so that they can be
+// fetched again with conditional requests. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the response stored for the key, if any.
+	Get(key string) (*CachedResponse, bool)
+
+	// Set stores the response for the key.
+	Set(key string, resp *CachedResponse)
+}
+
+// A CachedResponse is a successful response stored in a Cache.
+type CachedResponse struct {
+	// Header is the header of the response, which includes its `ETag` or
+	// `Last-Modified` header.
+	Header http.Header
+
+	// Body is the body of the response.
+	Body []byte
+}
+
+// maxCachedBodySize is the size of the largest response body that is cached.
+// Larger responses, such as exports, are passed through without buffering.
+const maxCachedBodySize = 1 << 20
+
+// WithCache makes the client cache the responses of GET requests that carry an
+// `ETag` or `Last-Modified` header. When a cached resource is requested again,
+// the client sends a conditional request, and returns the cached response if
+// the API responds with 304 Not Modified.
+//
+// Responses are cached by URL, so a cache should not be shared by clients
+// with different credentials.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// MemoryCache is a Cache that keeps the most recently used responses in memory.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewMemoryCache creates a cache that holds at most `maxEntries` responses,
+// evicting the least recently used response when it is full.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Get returns the response stored for the key, if any.
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+
+	return e.Value.(*memoryCacheEntry).resp, true
+}
+
+// Set stores the response for the key.
+func (c *MemoryCache) Set(key string, resp *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryCacheEntry).resp = resp
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryCacheEntry{key: key, resp: resp})
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// cachedRoundTrip sends the request, making it conditional on a cached
+// response if there is one.
+func (t *transport) cachedRoundTrip(req *http.Request) (*http.Response, error) {
+	cache := t.client.cache
+	if cache == nil || req.Method != http.MethodGet || req.Header.Get("Accept") == "text/event-stream" {
+		return t.roundTrip(req)
+	}
+
+	key := req.URL.String()
+	cached, ok := cache.Get(key)
+	if ok {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		resp.Body.Close()
+		resp = &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        cached.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       req,
+		}
+		recordResponse(req.Context(), resp)
+		return resp, nil
+	}
+
+	if resp.StatusCode != http.StatusOK || !isCacheable(resp.Header) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBodySize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	cache.Set(key, &CachedResponse{Header: resp.Header.Clone(), Body: body})
+
+	return resp, nil
+}
+
+// isCacheable reports whether a response with the header can be revalidated
+// with a conditional request and may be stored.
+func isCacheable(h http.Header) bool {
+	if h.Get("ETag") == "" && h.Get("Last-Modified") == "" {
+		return false
+	}
+
+	return !strings.Contains(h.Get("Cache-Control"), "no-store")
+}
@@ -0,0 +1,95 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_Cache(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":"test-project","displayName":"Test"}`))
+	}))
+	defer ts.Close()
+
+	cache := NewMemoryCache(10)
+	client := NewClient(http.DefaultClient, WithCache(cache))
+	client.baseURL = ts.URL
+
+	for i := 0; i < 2; i++ {
+		var resp Response
+		project, err := client.Projects.Get(WithResponse(context.Background(), &resp), "test-project")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if project.DisplayName != "Test" {
+			t.Errorf("Expected project 'Test', got %+v", project)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+	}
+
+	if requests != 2 || cache.Len() != 1 {
+		t.Errorf("Expected 2 requests and 1 cached response, got %d and %d", requests, cache.Len())
+	}
+}
+
+func TestClient_CacheSkipsUncacheableResponses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2021-06-07/projects/no-store" {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Cache-Control", "private, no-store")
+		}
+		if r.URL.Path == "/v2021-06-07/projects/large" {
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(`{"displayName":"` + strings.Repeat("x", maxCachedBodySize) + `"}`))
+			return
+		}
+		w.Write([]byte(`{"displayName":"Test"}`))
+	}))
+	defer ts.Close()
+
+	cache := NewMemoryCache(10)
+	client := NewClient(http.DefaultClient, WithCache(cache))
+	client.baseURL = ts.URL
+
+	for _, id := range []string{"no-etag", "no-store", "large"} {
+		if _, err := client.Projects.Get(context.Background(), id); err != nil {
+			t.Fatalf("%s: Expected no error, got %v", id, err)
+		}
+	}
+
+	project, err := client.Projects.Get(context.Background(), "large")
+	if err != nil || len(project.DisplayName) != maxCachedBodySize {
+		t.Errorf("Expected the large response to be passed through, got %d bytes (%v)", len(project.DisplayName), err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected no cached responses, got %d", cache.Len())
+	}
+}
+
+func TestMemoryCache_Evicts(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", &CachedResponse{})
+	cache.Set("b", &CachedResponse{})
+	cache.Get("a")
+	cache.Set("c", &CachedResponse{})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Expected the recently used entry to be kept")
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 )
 
+// Version is the version of this library. It is sent in the `User-Agent`
+// header of requests.
+const Version = "0.3.0"
+
 // NewBool accepts a bool and returns a pointer to a bool with the same value.
 //
 // The Sanity client uses bool pointers when bool values are optional parameters
@@ -26,79 +31,407 @@ type service struct {
 }
 
 // Client is a client for the Sanity HTTP API.
+//
+// The services of the client are referred to by interfaces, so that they can
+// be replaced with mocks in tests, e.g., generated with gomock or moq:
+//
+//	client := sanity.NewClient(nil)
+//	client.Projects = &mockProjects{}
 type Client struct {
 	// Projects is the client for the Projects API.
-	Projects *ProjectsService
+	Projects ProjectsAPI
 
 	// Webhooks is the client for the Webhooks API.
-	Webhooks *WebhooksService
+	Webhooks WebhooksAPI
+
+	// Documents is the client for the Doc and Query APIs.
+	Documents DocumentsAPI
+
+	// Actions is the client for the Actions API.
+	Actions ActionsAPI
+
+	// Assets is the client for the Assets API.
+	Assets AssetsAPI
+
+	// Listen is the client for the Listen API.
+	Listen ListenAPI
+
+	// Export is the client for the Export API.
+	Export ExportAPI
+
+	// Import is the client for importing documents into datasets.
+	Import ImportAPI
+
+	// History is the client for the History API.
+	History HistoryAPI
+
+	// GraphQL is the client for the GraphQL API.
+	GraphQL GraphQLAPI
+
+	// Users is the client for the Users API.
+	Users UsersAPI
+
+	// Organizations is the client for the Organizations API.
+	Organizations OrganizationsAPI
+
+	// Schedules is the client for the Scheduling API.
+	Schedules SchedulesAPI
+
+	// Releases is the client for content releases.
+	Releases ReleasesAPI
+
+	// Comments is the client for document comments.
+	Comments CommentsAPI
+
+	// Tasks is the client for studio tasks.
+	Tasks TasksAPI
+
+	// Embeddings is the client for the Embeddings Index API.
+	Embeddings EmbeddingsAPI
+
+	// AgentActions is the client for the Agent Actions API.
+	AgentActions AgentActionsAPI
+
+	// Schemas is the client for the schema store.
+	Schemas SchemasAPI
 
 	client *http.Client
 
+	// publicClient sends requests without the credentials of the client, e.g.,
+	// to download files that are not hosted by Sanity.
+	publicClient *http.Client
+
 	baseURL string
 
+	// projectHost builds the URLs of the project-scoped APIs.
+	projectHost projectHost
+
+	// maxRetries is the number of times a request that is rejected by rate
+	// limiting is retried.
+	maxRetries int
+
+	rateLimit rateLimitState
+
+	// timeout is the deadline applied to requests whose context has none.
+	timeout time.Duration
+
+	// userAgent is the value of the `User-Agent` header of requests.
+	userAgent string
+
+	transportOptions *TransportOptions
+
+	cache Cache
+
+	// slots holds a value for each request in flight if the number of
+	// requests in flight is limited.
+	slots chan struct{}
+
+	throttle *throttle
+
+	tracerProvider TracerProvider
+
+	metrics MetricsRecorder
+
+	// testProjectBaseURL is used for testing to override the per-project URL
+	// construction of the data APIs.
+	testProjectBaseURL string
+
 	common service
 }
 
+// A ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithRetries makes the client retry requests that are rejected for exceeding
+// the rate limit of the Sanity API, up to `maxRetries` times. Before each retry
+// the client waits for the time given by the `Retry-After` header of the
+// response, or backs off exponentially if there is none. Requests with a
+// streamed body, such as asset uploads, are not retried.
+//
+// Transactions of the Mutations and Actions APIs that have no transaction
+// identifier are given one by the client, so that a retried transaction cannot
+// be applied twice.
+func WithRetries(maxRetries int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithTimeout applies a default timeout to requests whose context has no
+// deadline. The timeout covers the whole request, including retries and
+// reading the response body. Requests with a context deadline, and the event
+// streams of the Listen API, are not affected.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent appends an application-specific suffix to the `User-Agent`
+// header of requests, e.g., `my-importer/1.2.0`, which identifies the
+// application in the logs of the Sanity API.
+func WithUserAgent(suffix string) ClientOption {
+	return func(c *Client) {
+		c.userAgent += " " + suffix
+	}
+}
+
+// WithMaxConcurrency limits the number of requests the client has in flight at
+// once to `n`, e.g., to protect the API from highly parallel jobs. Further
+// requests wait until a request completes or their context is done. A request
+// is in flight until its response is read, except for streamed responses, such
+// as exports and the results of QueryStream, which count only until their
+// headers arrive, so that requests can be made while a stream is read. The
+// streams of the Listen API are not limited.
+func WithMaxConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.slots = nil
+		if n > 0 {
+			c.slots = make(chan struct{}, n)
+		}
+	}
+}
+
 // NewClient creates a new Sanity client.
 //
 // If `httpClient` is nil, the `http.DefaultClient` will be used.
-// The `httpClient` is expected to provide authentication.
-func NewClient(httpClient *http.Client) *Client {
+// The `httpClient` is expected to provide authentication. It is not modified;
+// the client sends requests with a copy of it.
+func NewClient(httpClient *http.Client, opts ...ClientOption) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 	client := &Client{
-		client:  httpClient,
-		baseURL: "https://api.sanity.io",
+		baseURL:     "https://api.sanity.io",
+		projectHost: newProjectHost(defaultProjectHost),
+		userAgent:   "go-sanity/" + Version,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	hc := *httpClient
+	base := hc.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	base = client.transportOptions.tune(base)
+	hc.Transport = &transport{base: base, client: client}
+	client.client = &hc
+	client.publicClient = &http.Client{
+		Transport: &transport{base: client.transportOptions.tune(http.DefaultTransport), client: client},
 	}
 	client.common.client = client
 	client.Projects = (*ProjectsService)(&client.common)
 	client.Webhooks = &WebhooksService{service: client.common}
+	client.Documents = (*DocumentsService)(&client.common)
+	client.Actions = (*ActionsService)(&client.common)
+	client.Assets = (*AssetsService)(&client.common)
+	client.Listen = (*ListenService)(&client.common)
+	client.Export = (*ExportService)(&client.common)
+	client.Import = (*ImportService)(&client.common)
+	client.History = (*HistoryService)(&client.common)
+	client.GraphQL = (*GraphQLService)(&client.common)
+	client.Users = (*UsersService)(&client.common)
+	client.Organizations = (*OrganizationsService)(&client.common)
+	client.Schedules = (*SchedulesService)(&client.common)
+	client.Releases = (*ReleasesService)(&client.common)
+	client.Comments = (*CommentsService)(&client.common)
+	client.Tasks = (*TasksService)(&client.common)
+	client.Embeddings = (*EmbeddingsService)(&client.common)
+	client.AgentActions = (*AgentActionsService)(&client.common)
+	client.Schemas = (*SchemasService)(&client.common)
 
 	return client
 }
 
+// RateLimit returns the rate limit of the Sanity API as reported by the most
+// recent response.
+func (c *Client) RateLimit() RateLimit {
+	return c.rateLimit.get()
+}
+
+// Do sends a request to an endpoint of the Sanity API that the client does not
+// cover, with the authentication, retries, error handling, and instrumentation
+// of the client. The `path` is relative to the base URL of the APIs that are
+// not scoped to a project, e.g., `/v2021-06-07/projects/abc123/features`. It
+// may also be an absolute URL, e.g., for project-scoped APIs:
+//
+//	url := client.ProjectURL(projectId) + "/v2025-02-19/data/history/production/transactions"
+//	err := client.Do(ctx, http.MethodGet, url, nil, &result)
+//
+// The `body`, if not nil, is encoded as JSON, and the JSON response is decoded
+// into `out`. If `out` is nil, the response body is discarded. Unsuccessful
+// responses are returned as an *APIError.
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) error {
+	url := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		url = c.baseURL + "/" + strings.TrimPrefix(path, "/")
+	}
+
+	return do(ctx, c.client, url, method, body, out)
+}
+
+// ProjectURL returns the URL of the project-scoped APIs of the project, without
+// an API version, e.g., `https://abc123.api.sanity.io`, for use with Do.
+func (c *Client) ProjectURL(projectId string) string {
+	return c.projectHost.url(projectId)
+}
+
+// dataAPIVersion is the API version used for the project-scoped data APIs.
+const dataAPIVersion = "v2025-02-19"
+
+// projectBaseURL returns the base URL for operations that are scoped to a
+// single project, such as the data APIs.
+func (c *Client) projectBaseURL(projectId string) string {
+	if c.testProjectBaseURL != "" {
+		return c.testProjectBaseURL
+	}
+	return c.projectHost.url(projectId) + "/" + dataAPIVersion
+}
+
 func do(ctx context.Context, client *http.Client, url string, method string, body any, result any) error {
+	req, err := newRequest(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+
+	return doRequest(client, req, result)
+}
+
+// doRequest sends the request and decodes the JSON response into `result`. If
+// `result` is nil, the response body is discarded.
+func doRequest(client *http.Client, req *http.Request, result any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// openStream sends the request and returns the body of the response for the
+// caller to read. The caller is responsible for closing the body.
+func openStream(ctx context.Context, client *http.Client, method string, url string, body any) (io.ReadCloser, error) {
+	req, err := newRequest(context.WithValue(ctx, streamedKey{}, true), method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// newRequest creates a request with `body` encoded as JSON.
+func newRequest(ctx context.Context, method string, url string, body any) (*http.Request, error) {
 	var reader io.Reader
 	if body != nil {
 		b, err := json.Marshal(body)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		reader = bytes.NewReader(b)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := client.Do(req)
+	return req, nil
+}
+
+// APIError is the error returned when the Sanity API responds with an
+// unsuccessful status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Message is the human-readable description of the error given by the API,
+	// if any.
+	Message string
+
+	// ErrorType is the kind of error given by the API, if any, e.g.,
+	// `mutationError` or `Bad Request`.
+	ErrorType string
+
+	// Body is the raw body of the response.
+	Body []byte
+
+	// RequestId is the identifier the API assigned to the request, which is
+	// useful when reporting problems to Sanity.
+	RequestId string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, string(e.Body))
+}
+
+// checkResponse returns an *APIError describing the failure if the response
+// has an unsuccessful status code.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < 300 {
+		return nil
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestId:  resp.Header.Get("X-Request-Id"),
+	}
+
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		apiErr.Message = fmt.Sprintf("HTTP %d: failed to read error response", resp.StatusCode)
+		return apiErr
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode > 299 {
-		// Read the response body to handle both JSON and non-JSON error responses
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("HTTP %d: failed to read error response", resp.StatusCode)
-		}
+	apiErr.Body = body
 
-		// Try to parse as JSON error message first
-		type errorMessage struct {
-			Message string `json:"message"`
-		}
-		var msg errorMessage
-		if json.Unmarshal(body, &msg) == nil && msg.Message != "" {
-			return errors.New(msg.Message)
-		}
+	// The API describes errors either with a `message` attribute and an
+	// `error` string, or with an `error` object.
+	type errorMessage struct {
+		Message string          `json:"message"`
+		Error   json.RawMessage `json:"error"`
+	}
+	var msg errorMessage
+	if json.Unmarshal(body, &msg) != nil {
+		return apiErr
+	}
+	apiErr.Message = msg.Message
 
-		// Fallback to descriptive HTTP error with response body
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	var errorType string
+	if json.Unmarshal(msg.Error, &errorType) == nil {
+		apiErr.ErrorType = errorType
+	}
+	var errorObject struct {
+		Type        string `json:"type"`
+		Description string `json:"description"`
+	}
+	if json.Unmarshal(msg.Error, &errorObject) == nil {
+		apiErr.ErrorType = errorObject.Type
+		if apiErr.Message == "" {
+			apiErr.Message = errorObject.Description
+		}
 	}
 
-	return json.NewDecoder(resp.Body).Decode(result)
+	return apiErr
 }
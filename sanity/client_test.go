@@ -0,0 +1,149 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckResponse_APIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expectMessage string
+		expectType    string
+		expectError   string
+	}{
+		{
+			name:          "message",
+			body:          `{"statusCode":403,"error":"Forbidden","message":"Insufficient permissions"}`,
+			expectMessage: "Insufficient permissions",
+			expectType:    "Forbidden",
+			expectError:   "Insufficient permissions",
+		},
+		{
+			name:          "error object",
+			body:          `{"error":{"type":"mutationError","description":"Document already exists"}}`,
+			expectMessage: "Document already exists",
+			expectType:    "mutationError",
+			expectError:   "Document already exists",
+		},
+		{
+			name:        "plain text",
+			body:        `upstream unavailable`,
+			expectError: "HTTP 403: upstream unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req-1")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			client := NewClient(http.DefaultClient)
+			client.baseURL = ts.URL
+
+			_, err := client.Projects.List(context.Background())
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected an *APIError, got %v", err)
+			}
+			if apiErr.StatusCode != http.StatusForbidden || apiErr.RequestId != "req-1" || string(apiErr.Body) != tt.body {
+				t.Errorf("Unexpected error %+v", apiErr)
+			}
+			if apiErr.Message != tt.expectMessage || apiErr.ErrorType != tt.expectType {
+				t.Errorf("Expected message '%s' and type '%s', got '%s' and '%s'", tt.expectMessage, tt.expectType, apiErr.Message, apiErr.ErrorType)
+			}
+			if err.Error() != tt.expectError {
+				t.Errorf("Expected error '%s', got '%s'", tt.expectError, err.Error())
+			}
+		})
+	}
+}
+
+// mockDocuments is a DocumentsAPI that records the mutations applied with it.
+type mockDocuments struct {
+	DocumentsAPI
+	mutations []Mutation
+}
+
+func (m *mockDocuments) Mutate(ctx context.Context, projectId, dataset string, r *MutateRequest) (*MutateResponse, error) {
+	m.mutations = append(m.mutations, r.Mutations...)
+	return &MutateResponse{TransactionId: "tx"}, nil
+}
+
+func TestClient_ServiceMock(t *testing.T) {
+	client := NewClient(nil)
+	mock := &mockDocuments{}
+	client.Documents = mock
+
+	resp, err := client.Import.Import(context.Background(), "test-project", "production", &ImportRequest{
+		Documents: strings.NewReader(`{"_id":"a","_type":"movie"}` + "\n" + `{"_id":"b","_type":"movie"}`),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Documents != 2 || len(mock.mutations) != 2 {
+		t.Errorf("Expected the import to use the mock, got %d mutations", len(mock.mutations))
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	var userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithUserAgent("my-importer/1.2.0"))
+	client.baseURL = ts.URL
+
+	if _, err := client.Projects.List(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := "go-sanity/" + Version + " my-importer/1.2.0"; userAgent != expected {
+		t.Errorf("Expected user agent '%s', got '%s'", expected, userAgent)
+	}
+}
+
+func TestClient_Do(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2021-06-07/projects/test-project/features" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode":404,"error":"Not Found","message":"Unknown endpoint"}`))
+			return
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON POST request, got %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		w.Write([]byte(`["privateDataset"]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	var features []string
+	err := client.Do(context.Background(), http.MethodPost, "v2021-06-07/projects/test-project/features", map[string]any{}, &features)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(features) != 1 || features[0] != "privateDataset" {
+		t.Errorf("Expected [privateDataset], got %v", features)
+	}
+
+	err = client.Do(context.Background(), http.MethodGet, ts.URL+"/v1/unknown", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Unknown endpoint" {
+		t.Errorf("Expected a 404 APIError, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package sanity
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CommentsService is a client for the comments that editors leave on documents
+// in the studio.
+//
+// Comments are stored as documents of type `comment` in the comments addon
+// dataset of a dataset, which is named after the dataset with a `-comments`
+// suffix. The functions of CommentsService accept the name of the content
+// dataset and resolve the addon dataset themselves.
+type CommentsService service
+
+const (
+	CommentStatusOpen     = "open"
+	CommentStatusResolved = "resolved"
+)
+
+// A Comment is a message left on a document.
+type Comment struct {
+	// Id is the unique identifier for the comment.
+	Id string `json:"_id"`
+
+	// AuthorId is the identifier of the user who wrote the comment.
+	AuthorId string `json:"authorId"`
+
+	// Author is the user who wrote the comment. It is only populated by
+	// functions that resolve authors, such as Threads.
+	Author *User `json:"-"`
+
+	// ThreadId is the identifier of the thread of the comment. The first
+	// comment of a thread and its replies share the same thread identifier.
+	ThreadId string `json:"threadId"`
+
+	// ParentCommentId is the identifier of the comment that this comment
+	// replies to. It is empty for the first comment of a thread.
+	ParentCommentId string `json:"parentCommentId,omitempty"`
+
+	// Status is the status of the thread of the comment. Valid values are
+	// represented as the `CommentStatus*` constants in this package.
+	Status string `json:"status"`
+
+	// Message is the content of the comment as Portable Text.
+	Message json.RawMessage `json:"message,omitempty"`
+
+	// Reactions are the reactions of users to the comment.
+	Reactions []CommentReaction `json:"reactions,omitempty"`
+
+	// Target identifies the document the comment is left on.
+	Target CommentTarget `json:"target"`
+
+	// CreatedAt is the time the comment was created.
+	CreatedAt time.Time `json:"_createdAt"`
+}
+
+// A CommentReaction is a reaction of a user to a comment.
+type CommentReaction struct {
+	// Key is the unique key of the reaction within the comment.
+	Key string `json:"_key"`
+
+	// ShortName is the short name of the reaction emoji, e.g., `:+1:`.
+	ShortName string `json:"shortName"`
+
+	// UserId is the identifier of the user who reacted.
+	UserId string `json:"userId"`
+
+	// AddedAt is the time the reaction was added.
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// CommentTarget identifies the document a comment is left on.
+type CommentTarget struct {
+	// DocumentRef is a reference to the published document.
+	DocumentRef struct {
+		Ref string `json:"_ref"`
+	} `json:"documentRef"`
+
+	// DocumentType is the type of the document.
+	DocumentType string `json:"documentType,omitempty"`
+
+	// Path identifies the field the comment is left on, if any.
+	Path struct {
+		Field string `json:"field,omitempty"`
+	} `json:"path"`
+}
+
+// commentsDataset returns the name of the comments addon dataset of the
+// dataset.
+func commentsDataset(dataset string) string {
+	return dataset + "-comments"
+}
+
+// Get fetches a single comment. A nil Comment is returned if the comment does
+// not exist.
+func (s *CommentsService) Get(ctx context.Context, projectId, dataset, commentId string) (*Comment, error) {
+	var comment *Comment
+	err := s.client.Documents.Query(ctx, projectId, commentsDataset(dataset), &QueryRequest{
+		Query:  `*[_type == "comment" && _id == $id][0]`,
+		Params: map[string]any{"id": commentId},
+	}, &comment)
+
+	return comment, err
+}
+
+// A CommentThread is a comment and its replies.
+type CommentThread struct {
+	// Id is the identifier of the thread.
+	Id string
+
+	// Status is the status of the thread. Valid values are represented as the
+	// `CommentStatus*` constants in this package.
+	Status string
+
+	// Comment is the first comment of the thread.
+	Comment Comment
+
+	// Replies are the replies to the first comment, oldest first.
+	Replies []Comment
+}
+
+// Threads returns the comments left on a document, organized into threads
+// with the oldest thread first. The authors of the comments are resolved to
+// the users of the project.
+func (s *CommentsService) Threads(ctx context.Context, projectId, dataset, documentId string) ([]CommentThread, error) {
+	var comments []Comment
+	err := s.client.Documents.Query(ctx, projectId, commentsDataset(dataset), &QueryRequest{
+		Query:  `*[_type == "comment" && target.documentRef._ref == $documentId] | order(_createdAt asc)`,
+		Params: map[string]any{"documentId": documentId},
+	}, &comments)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := map[string]*User{}
+	for i := range comments {
+		id := comments[i].AuthorId
+		if id == "" {
+			continue
+		}
+		if _, ok := authors[id]; !ok {
+			user, err := s.client.Projects.GetUser(ctx, projectId, id)
+			if err != nil {
+				return nil, fmt.Errorf("resolving author %s: %w", id, err)
+			}
+			authors[id] = user
+		}
+		comments[i].Author = authors[id]
+	}
+
+	return threadComments(comments), nil
+}
+
+// threadComments groups the comments, which are ordered by creation time, into
+// threads. If the first comment of a thread is missing, the oldest reply takes
+// its place.
+func threadComments(comments []Comment) []CommentThread {
+	var threads []CommentThread
+	index := map[string]int{}
+	for _, comment := range comments {
+		i, ok := index[comment.ThreadId]
+		if !ok {
+			index[comment.ThreadId] = len(threads)
+			threads = append(threads, CommentThread{Id: comment.ThreadId, Status: comment.Status, Comment: comment})
+			continue
+		}
+
+		thread := &threads[i]
+		if comment.ParentCommentId == "" && thread.Comment.ParentCommentId != "" {
+			thread.Replies = append(thread.Replies, thread.Comment)
+			thread.Comment = comment
+			thread.Status = comment.Status
+			continue
+		}
+		thread.Replies = append(thread.Replies, comment)
+	}
+
+	for i := range threads {
+		replies := threads[i].Replies
+		sort.SliceStable(replies, func(a, b int) bool {
+			return replies[a].CreatedAt.Before(replies[b].CreatedAt)
+		})
+	}
+
+	return threads
+}
+
+// AddReaction adds a reaction of a user to a comment. The short name is the
+// name of the reaction emoji, e.g., `:+1:`.
+func (s *CommentsService) AddReaction(ctx context.Context, projectId, dataset, commentId, userId, shortName string) error {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+
+	reaction := CommentReaction{
+		Key:       hex.EncodeToString(b),
+		ShortName: shortName,
+		UserId:    userId,
+		AddedAt:   time.Now().UTC(),
+	}
+
+	return s.mutate(ctx, projectId, dataset,
+		Mutation{Patch: &Patch{Id: commentId, SetIfMissing: map[string]any{"reactions": []any{}}}},
+		Mutation{Patch: &Patch{Id: commentId, Insert: &PatchInsert{After: "reactions[-1]", Items: []any{reaction}}}},
+	)
+}
+
+// RemoveReaction removes the reactions of a user with the short name from a
+// comment. It does nothing if the user has not reacted with the short name.
+func (s *CommentsService) RemoveReaction(ctx context.Context, projectId, dataset, commentId, userId, shortName string) error {
+	comment, err := s.Get(ctx, projectId, dataset, commentId)
+	if err != nil {
+		return err
+	}
+	if comment == nil {
+		return fmt.Errorf("comment %s not found", commentId)
+	}
+
+	var unset []string
+	for _, reaction := range comment.Reactions {
+		if reaction.UserId == userId && reaction.ShortName == shortName {
+			unset = append(unset, fmt.Sprintf("reactions[_key==%q]", reaction.Key))
+		}
+	}
+	if len(unset) == 0 {
+		return nil
+	}
+
+	return s.mutate(ctx, projectId, dataset, Mutation{Patch: &Patch{Id: commentId, Unset: unset}})
+}
+
+// Resolve marks the comments of a thread as resolved.
+func (s *CommentsService) Resolve(ctx context.Context, projectId, dataset, threadId string) error {
+	return s.setThreadStatus(ctx, projectId, dataset, threadId, CommentStatusResolved)
+}
+
+// Reopen marks the comments of a resolved thread as open.
+func (s *CommentsService) Reopen(ctx context.Context, projectId, dataset, threadId string) error {
+	return s.setThreadStatus(ctx, projectId, dataset, threadId, CommentStatusOpen)
+}
+
+// setThreadStatus sets the status of all the comments of a thread.
+func (s *CommentsService) setThreadStatus(ctx context.Context, projectId, dataset, threadId, status string) error {
+	return s.mutate(ctx, projectId, dataset, Mutation{Patch: &Patch{
+		Query:  `*[_type == "comment" && threadId == $threadId]`,
+		Params: map[string]any{"threadId": threadId},
+		Set:    map[string]any{"status": status},
+	}})
+}
+
+// mutate applies the mutations to the comments addon dataset of the dataset.
+func (s *CommentsService) mutate(ctx context.Context, projectId, dataset string, mutations ...Mutation) error {
+	_, err := s.client.Documents.Mutate(ctx, projectId, commentsDataset(dataset), &MutateRequest{
+		Mutations: mutations,
+		ReturnIds: NewBool(false),
+	})
+
+	return err
+}
@@ -0,0 +1,172 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCommentsService_AddReaction(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/mutate/production-comments" {
+			t.Errorf("Expected /data/mutate/production-comments path, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			Mutations []struct {
+				Patch map[string]any `json:"patch"`
+			} `json:"mutations"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Mutations) != 2 {
+			t.Fatalf("Expected 2 mutations, got %d", len(body.Mutations))
+		}
+		insert, _ := body.Mutations[1].Patch["insert"].(map[string]any)
+		items, _ := insert["items"].([]any)
+		if body.Mutations[1].Patch["id"] != "comment-1" || insert["after"] != "reactions[-1]" || len(items) != 1 {
+			t.Fatalf("Unexpected insert patch %v", body.Mutations[1].Patch)
+		}
+		reaction := items[0].(map[string]any)
+		if reaction["shortName"] != ":+1:" || reaction["userId"] != "user-1" || reaction["_key"] == "" {
+			t.Errorf("Unexpected reaction %v", reaction)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	if err := client.Comments.AddReaction(context.Background(), "test-project", "production", "comment-1", "user-1", ":+1:"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestCommentsService_RemoveReaction(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/data/query/production-comments":
+			w.Write([]byte(`{"result":{"_id":"comment-1","reactions":[{"_key":"a","shortName":":+1:","userId":"user-1"},{"_key":"b","shortName":":+1:","userId":"user-2"}]}}`))
+		case "/data/mutate/production-comments":
+			var body struct {
+				Mutations []struct {
+					Patch Patch `json:"patch"`
+				} `json:"mutations"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			unset := body.Mutations[0].Patch.Unset
+			if len(unset) != 1 || unset[0] != `reactions[_key=="a"]` {
+				t.Errorf("Unexpected unset paths %v", unset)
+			}
+			w.Write([]byte(`{"transactionId":"tx-1"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	if err := client.Comments.RemoveReaction(context.Background(), "test-project", "production", "comment-1", "user-1", ":+1:"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestCommentsService_Resolve(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Mutations []struct {
+				Patch Patch `json:"patch"`
+			} `json:"mutations"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		patch := body.Mutations[0].Patch
+		if patch.Params["threadId"] != "thread-1" || patch.Set["status"] != CommentStatusResolved {
+			t.Errorf("Unexpected patch %+v", patch)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	if err := client.Comments.Resolve(context.Background(), "test-project", "production", "thread-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestCommentsService_Threads(t *testing.T) {
+	userLookups := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/data/query/production-comments":
+			var req QueryRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Params["documentId"] != "post_1" {
+				t.Errorf("Unexpected params %v", req.Params)
+			}
+			w.Write([]byte(`{"result":[
+				{"_id":"c1","threadId":"t1","authorId":"user-1","status":"open","_createdAt":"2024-01-01T00:00:00Z"},
+				{"_id":"c2","threadId":"t2","authorId":"user-2","status":"resolved","_createdAt":"2024-01-02T00:00:00Z"},
+				{"_id":"c3","threadId":"t1","parentCommentId":"c1","authorId":"user-2","status":"open","_createdAt":"2024-01-03T00:00:00Z"}
+			]}`))
+		case "/v2021-06-07/projects/test-project/users/user-1":
+			userLookups++
+			w.Write([]byte(`{"id":"user-1","displayName":"Ada"}`))
+		case "/v2021-06-07/projects/test-project/users/user-2":
+			userLookups++
+			w.Write([]byte(`{"id":"user-2","displayName":"Grace"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+	client.testProjectBaseURL = ts.URL
+
+	threads, err := client.Comments.Threads(context.Background(), "test-project", "production", "post_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(threads) != 2 {
+		t.Fatalf("Expected 2 threads, got %d", len(threads))
+	}
+	if threads[0].Id != "t1" || threads[0].Comment.Id != "c1" || len(threads[0].Replies) != 1 || threads[0].Replies[0].Id != "c3" {
+		t.Errorf("Unexpected first thread %+v", threads[0])
+	}
+	if threads[0].Replies[0].Author == nil || threads[0].Replies[0].Author.DisplayName != "Grace" {
+		t.Errorf("Expected the reply author to be resolved, got %+v", threads[0].Replies[0].Author)
+	}
+	if threads[1].Id != "t2" || threads[1].Status != CommentStatusResolved || len(threads[1].Replies) != 0 {
+		t.Errorf("Unexpected second thread %+v", threads[1])
+	}
+	if userLookups != 2 {
+		t.Errorf("Expected 2 user lookups, got %d", userLookups)
+	}
+}
+
+func TestThreadComments_MissingParent(t *testing.T) {
+	threads := threadComments([]Comment{
+		{Id: "c2", ThreadId: "t1", ParentCommentId: "c0"},
+		{Id: "c1", ThreadId: "t1"},
+	})
+
+	if len(threads) != 1 || threads[0].Comment.Id != "c1" || len(threads[0].Replies) != 1 || threads[0].Replies[0].Id != "c2" {
+		t.Errorf("Unexpected threads %+v", threads)
+	}
+}
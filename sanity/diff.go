@@ -0,0 +1,168 @@
+package sanity
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// A FieldChange describes a difference between two revisions of a document.
+type FieldChange struct {
+	// Path is the path of the field in the document, e.g., `title`,
+	// `cast[2].name`, or `body[_key=="abc"].text`. Elements of arrays whose
+	// items all have a `_key` attribute are identified by their key.
+	Path string
+
+	// Type is the type of the change. Valid values are represented as the
+	// `Change*` constants in this package.
+	Type string
+
+	// Before is the value of the field in the earlier revision. It is nil if
+	// the field was added.
+	Before any
+
+	// After is the value of the field in the later revision. It is nil if the
+	// field was removed.
+	After any
+}
+
+// DiffDocuments compares two revisions of a document and returns the changed
+// fields, ordered by path. Changes are reported for the innermost fields that
+// differ, so a changed attribute of a nested object is reported without its
+// parent. The `_rev` and `_updatedAt` attributes are ignored, as they change
+// with every revision.
+func DiffDocuments(before, after Document) []FieldChange {
+	b, a := map[string]any(before), map[string]any(after)
+	if b == nil {
+		b = map[string]any{}
+	}
+	if a == nil {
+		a = map[string]any{}
+	}
+
+	var changes []FieldChange
+	diffObjects("", b, a, &changes)
+
+	return changes
+}
+
+// diffObjects appends the changes between two objects at the path.
+func diffObjects(path string, before, after map[string]any, changes *[]FieldChange) {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if path == "" && (key == "_rev" || key == "_updatedAt") {
+			continue
+		}
+
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		b, inBefore := before[key]
+		a, inAfter := after[key]
+		switch {
+		case !inBefore:
+			*changes = append(*changes, FieldChange{Path: fieldPath, Type: ChangeAdded, After: a})
+		case !inAfter:
+			*changes = append(*changes, FieldChange{Path: fieldPath, Type: ChangeRemoved, Before: b})
+		default:
+			diffValues(fieldPath, b, a, changes)
+		}
+	}
+}
+
+// diffArrays appends the changes between two arrays at the path. Keyed items
+// are matched by key, and other items by index.
+func diffArrays(path string, before, after []any, changes *[]FieldChange) {
+	if beforeKeys, afterKeys := arrayKeys(before), arrayKeys(after); beforeKeys != nil && afterKeys != nil {
+		afterIndex := make(map[string]int, len(afterKeys))
+		for i, key := range afterKeys {
+			afterIndex[key] = i
+		}
+
+		seen := make(map[string]bool, len(beforeKeys))
+		for i, key := range beforeKeys {
+			seen[key] = true
+			itemPath := fmt.Sprintf("%s[_key==%q]", path, key)
+			if j, ok := afterIndex[key]; ok {
+				diffValues(itemPath, before[i], after[j], changes)
+			} else {
+				*changes = append(*changes, FieldChange{Path: itemPath, Type: ChangeRemoved, Before: before[i]})
+			}
+		}
+		for j, key := range afterKeys {
+			if !seen[key] {
+				itemPath := fmt.Sprintf("%s[_key==%q]", path, key)
+				*changes = append(*changes, FieldChange{Path: itemPath, Type: ChangeAdded, After: after[j]})
+			}
+		}
+
+		return
+	}
+
+	for i := 0; i < len(before) || i < len(after); i++ {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(before):
+			*changes = append(*changes, FieldChange{Path: itemPath, Type: ChangeAdded, After: after[i]})
+		case i >= len(after):
+			*changes = append(*changes, FieldChange{Path: itemPath, Type: ChangeRemoved, Before: before[i]})
+		default:
+			diffValues(itemPath, before[i], after[i], changes)
+		}
+	}
+}
+
+// diffValues appends the changes between two values at the path, comparing
+// objects and arrays field by field.
+func diffValues(path string, before, after any, changes *[]FieldChange) {
+	switch b := before.(type) {
+	case map[string]any:
+		if a, ok := after.(map[string]any); ok {
+			diffObjects(path, b, a, changes)
+			return
+		}
+	case []any:
+		if a, ok := after.([]any); ok {
+			diffArrays(path, b, a, changes)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, FieldChange{Path: path, Type: ChangeChanged, Before: before, After: after})
+	}
+}
+
+// arrayKeys returns the `_key` attributes of the items of the array, or nil if
+// any item does not have a key.
+func arrayKeys(items []any) []string {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		obj, _ := item.(map[string]any)
+		key, _ := obj["_key"].(string)
+		if key == "" {
+			return nil
+		}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,58 @@
+package sanity
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffDocuments(t *testing.T) {
+	var before, after Document
+	json.Unmarshal([]byte(`{
+		"_id": "movie_1",
+		"_rev": "rev-1",
+		"title": "Alien",
+		"year": 1979,
+		"tags": ["horror", "space"],
+		"director": {"name": "Ridley Scott"},
+		"cast": [
+			{"_key": "a", "name": "Sigourney Weaver"},
+			{"_key": "b", "name": "Tom Skerritt"}
+		]
+	}`), &before)
+	json.Unmarshal([]byte(`{
+		"_id": "movie_1",
+		"_rev": "rev-2",
+		"title": "Alien",
+		"tags": ["horror", "sci-fi", "classic"],
+		"director": {"name": "Ridley Scott", "born": 1937},
+		"cast": [
+			{"_key": "a", "name": "Sigourney Weaver"},
+			{"_key": "c", "name": "John Hurt"}
+		],
+		"rating": "R"
+	}`), &after)
+
+	expected := []FieldChange{
+		{Path: `cast[_key=="b"]`, Type: ChangeRemoved, Before: map[string]any{"_key": "b", "name": "Tom Skerritt"}},
+		{Path: `cast[_key=="c"]`, Type: ChangeAdded, After: map[string]any{"_key": "c", "name": "John Hurt"}},
+		{Path: "director.born", Type: ChangeAdded, After: float64(1937)},
+		{Path: "rating", Type: ChangeAdded, After: "R"},
+		{Path: "tags[1]", Type: ChangeChanged, Before: "space", After: "sci-fi"},
+		{Path: "tags[2]", Type: ChangeAdded, After: "classic"},
+		{Path: "year", Type: ChangeRemoved, Before: float64(1979)},
+	}
+
+	changes := DiffDocuments(before, after)
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected changes %+v, got %+v", expected, changes)
+	}
+}
+
+func TestDiffDocuments_Identical(t *testing.T) {
+	doc := Document{"_id": "a", "title": "Alien"}
+
+	if changes := DiffDocuments(doc, doc); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
@@ -0,0 +1,278 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DocumentsService is a client for the Sanity Doc and Query APIs.
+//
+// Refer to https://www.sanity.io/docs/http-api for more information.
+type DocumentsService service
+
+// A Document is a Sanity document.
+//
+// Documents are schemaless JSON objects, so their attributes are exposed as a
+// generic map. Use the accessor methods to read the system attributes.
+type Document map[string]any
+
+// Id returns the `_id` attribute of the document.
+func (d Document) Id() string {
+	id, _ := d["_id"].(string)
+	return id
+}
+
+// Type returns the `_type` attribute of the document.
+func (d Document) Type() string {
+	t, _ := d["_type"].(string)
+	return t
+}
+
+// Rev returns the `_rev` attribute of the document.
+func (d Document) Rev() string {
+	rev, _ := d["_rev"].(string)
+	return rev
+}
+
+// Get fetches a single document by its unique identifier.
+//
+// This is cheaper than issuing a query for single-document lookups. A nil
+// Document is returned if the document does not exist or is not accessible
+// with the credentials of the client.
+func (s *DocumentsService) Get(ctx context.Context, projectId, dataset, docId string) (Document, error) {
+	docs, _, err := s.GetMany(ctx, projectId, dataset, []string{docId})
+	if err != nil || len(docs) == 0 {
+		return nil, err
+	}
+
+	return docs[0], nil
+}
+
+// GetMany fetches multiple documents by their unique identifiers in a single
+// request.
+//
+// The found documents are returned along with the identifiers of any documents
+// that do not exist or are not accessible with the credentials of the client.
+func (s *DocumentsService) GetMany(ctx context.Context, projectId, dataset string, docIds []string) ([]Document, []string, error) {
+	if len(docIds) == 0 {
+		return nil, nil, errors.New("at least one document id is required")
+	}
+
+	url := fmt.Sprintf("%s/data/doc/%s/%s", s.client.projectBaseURL(projectId), dataset, strings.Join(docIds, ","))
+
+	type response struct {
+		Documents []Document `json:"documents"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[string]bool, len(resp.Documents))
+	for _, doc := range resp.Documents {
+		found[doc.Id()] = true
+	}
+
+	var missing []string
+	for _, id := range docIds {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return resp.Documents, missing, nil
+}
+
+// -----------------------------------------------------------------------------
+// Query
+
+// QueryRequest describes a GROQ query to execute against a dataset.
+type QueryRequest struct {
+	// Query is the GROQ query to execute.
+	Query string `json:"query"`
+
+	// Params are the values for the parameters referenced in the query. A
+	// parameter `$name` in the query is supplied with the key `name`.
+	Params map[string]any `json:"params,omitempty"`
+
+	// Perspective selects which documents the query sees. Valid values are
+	// represented as the `Perspective*` constants in this package, or a stack
+	// of releases returned by ReleasePerspective. Defaults to
+	// PerspectivePublished.
+	Perspective string `json:"-"`
+}
+
+const (
+	// PerspectivePublished shows only published documents.
+	PerspectivePublished = "published"
+
+	// PerspectiveDrafts shows drafts in place of published documents where
+	// drafts exist.
+	PerspectiveDrafts = "drafts"
+
+	// PerspectiveRaw shows all documents, including drafts and versions, under
+	// their own identifiers.
+	PerspectiveRaw = "raw"
+)
+
+// queryURL returns the URL of the Query API for the request.
+func (s *DocumentsService) queryURL(projectId, dataset string, r *QueryRequest) string {
+	params := url.Values{}
+	if r.Perspective != "" {
+		params.Set("perspective", r.Perspective)
+	}
+
+	url := fmt.Sprintf("%s/data/query/%s", s.client.projectBaseURL(projectId), dataset)
+	if len(params) > 0 {
+		url += "?" + params.Encode()
+	}
+
+	return url
+}
+
+// Query executes a GROQ query and decodes its result into `result`.
+//
+// The `result` argument should be a pointer to a value that matches the shape
+// of the query result, such as a `*[]Document` or a pointer to a struct.
+func (s *DocumentsService) Query(ctx context.Context, projectId, dataset string, r *QueryRequest, result any) error {
+	url := s.queryURL(projectId, dataset, r)
+
+	type response struct {
+		Result json.RawMessage `json:"result"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodPost, r, &resp)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp.Result, result)
+}
+
+// QueryStream executes a GROQ query that evaluates to an array and calls `fn`
+// for each document in the result as it is decoded.
+//
+// Unlike Query, the result is never buffered in memory as a whole, which makes
+// QueryStream suitable for queries returning tens of thousands of documents.
+// Decoding stops at the first error returned by `fn`, and that error is
+// returned to the caller.
+func (s *DocumentsService) QueryStream(ctx context.Context, projectId, dataset string, r *QueryRequest, fn func(Document) error) error {
+	url := s.queryURL(projectId, dataset, r)
+
+	body, err := openStream(ctx, s.client.client, http.MethodPost, url, r)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if key != "result" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return fmt.Errorf("query result is not an array: %w", err)
+		}
+		for dec.More() {
+			var doc Document
+			if err := dec.Decode(&doc); err != nil {
+				return err
+			}
+			if err := fn(doc); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// expectDelim reads the next token from `dec` and returns an error if it is not
+// the delimiter `delim`.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %q, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// PaginateQueryRequest describes a GROQ query whose results are fetched one
+// page at a time.
+type PaginateQueryRequest struct {
+	// Query is the GROQ query to execute. It must evaluate to an array, and it
+	// must not apply its own ordering or slicing, e.g., `*[_type == "movie"]`.
+	Query string
+
+	// Params are the values for the parameters referenced in the query.
+	Params map[string]any
+
+	// PageSize is the maximum number of documents in a page. Defaults to 100.
+	PageSize int
+
+	// OrderBy is the ordering applied to the query so that pages are stable,
+	// e.g., `_createdAt desc`. Defaults to `_id asc`.
+	OrderBy string
+
+	// Perspective selects which documents the query sees. See the Perspective
+	// attribute of QueryRequest.
+	Perspective string
+}
+
+// A QueryPaginator fetches the results of a query one page at a time.
+type QueryPaginator = Pages[Document]
+
+// PaginateQuery returns a paginator that iterates the results of the query
+// until they are exhausted. Each page is fetched by applying an ordering and a
+// slice to the query.
+func (s *DocumentsService) PaginateQuery(projectId, dataset string, r *PaginateQueryRequest) *QueryPaginator {
+	req := *r
+	if req.PageSize <= 0 {
+		req.PageSize = 100
+	}
+	if req.OrderBy == "" {
+		req.OrderBy = "_id asc"
+	}
+
+	return newPages(func(ctx context.Context, cursor string) ([]Document, string, error) {
+		offset, _ := strconv.Atoi(cursor)
+		query := fmt.Sprintf("%s | order(%s) [%d...%d]", req.Query, req.OrderBy, offset, offset+req.PageSize)
+
+		var page []Document
+		err := s.Query(ctx, projectId, dataset, &QueryRequest{Query: query, Params: req.Params, Perspective: req.Perspective}, &page)
+		if err != nil || len(page) < req.PageSize {
+			return page, "", err
+		}
+
+		return page, strconv.Itoa(offset + len(page)), nil
+	})
+}
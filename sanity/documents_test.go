@@ -0,0 +1,247 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDocumentsService_Get(t *testing.T) {
+	// Create a test server that returns a single document
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/data/doc/production/movie_123" {
+			t.Errorf("Expected /data/doc/production/movie_123 path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"documents":[{"_id":"movie_123","_type":"movie","_rev":"abc","title":"Alien"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	doc, err := client.Documents.Get(context.Background(), "test-project", "production", "movie_123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if doc.Id() != "movie_123" {
+		t.Errorf("Expected document ID 'movie_123', got '%s'", doc.Id())
+	}
+	if doc.Type() != "movie" {
+		t.Errorf("Expected document type 'movie', got '%s'", doc.Type())
+	}
+	if doc.Rev() != "abc" {
+		t.Errorf("Expected document revision 'abc', got '%s'", doc.Rev())
+	}
+	if doc["title"] != "Alien" {
+		t.Errorf("Expected title 'Alien', got '%v'", doc["title"])
+	}
+}
+
+func TestDocumentsService_Get_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"documents":[],"omitted":[{"id":"missing","reason":"existence"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	doc, err := client.Documents.Get(context.Background(), "test-project", "production", "missing")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if doc != nil {
+		t.Errorf("Expected nil document, got %v", doc)
+	}
+}
+
+func TestClient_ProjectBaseURL(t *testing.T) {
+	client := NewClient(nil)
+
+	expectedURL := "https://test-project.api.sanity.io/v2025-02-19"
+	if actualURL := client.projectBaseURL("test-project"); actualURL != expectedURL {
+		t.Errorf("Expected base URL '%s', got '%s'", expectedURL, actualURL)
+	}
+}
+
+func TestDocumentsService_GetMany(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/doc/production/a,b,c" {
+			t.Errorf("Expected /data/doc/production/a,b,c path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"documents":[{"_id":"a","_type":"movie"},{"_id":"c","_type":"movie"}],"omitted":[{"id":"b","reason":"existence"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	docs, missing, err := client.Documents.GetMany(context.Background(), "test-project", "production", []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("Expected 2 documents, got %d", len(docs))
+	}
+	if docs[0].Id() != "a" || docs[1].Id() != "c" {
+		t.Errorf("Expected documents 'a' and 'c', got '%s' and '%s'", docs[0].Id(), docs[1].Id())
+	}
+	if len(missing) != 1 || missing[0] != "b" {
+		t.Errorf("Expected missing IDs [b], got %v", missing)
+	}
+}
+
+func TestDocumentsService_Query(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/data/query/production" {
+			t.Errorf("Expected /data/query/production path, got %s", r.URL.Path)
+		}
+
+		var req QueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if req.Query != `*[_type == $type]` {
+			t.Errorf("Unexpected query '%s'", req.Query)
+		}
+		if req.Params["type"] != "movie" {
+			t.Errorf("Expected param type 'movie', got '%v'", req.Params["type"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ms":3,"query":"*[_type == $type]","result":[{"_id":"a","title":"Alien"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	type movie struct {
+		Id    string `json:"_id"`
+		Title string `json:"title"`
+	}
+
+	var movies []movie
+	req := &QueryRequest{Query: `*[_type == $type]`, Params: map[string]any{"type": "movie"}}
+	if err := client.Documents.Query(context.Background(), "test-project", "production", req, &movies); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(movies) != 1 || movies[0].Title != "Alien" {
+		t.Errorf("Expected a single movie 'Alien', got %v", movies)
+	}
+}
+
+func TestDocumentsService_PaginateQuery(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		queries = append(queries, req.Query)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch len(queries) {
+		case 1:
+			w.Write([]byte(`{"result":[{"_id":"a"},{"_id":"b"}]}`))
+		default:
+			w.Write([]byte(`{"result":[{"_id":"c"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	p := client.Documents.PaginateQuery("test-project", "production", &PaginateQueryRequest{
+		Query:    `*[_type == "movie"]`,
+		PageSize: 2,
+	})
+
+	var ids []string
+	ctx := context.Background()
+	for p.Next(ctx) {
+		for _, doc := range p.Page() {
+			ids = append(ids, doc.Id())
+		}
+	}
+	if err := p.Err(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("Expected IDs a,b,c, got %v", ids)
+	}
+
+	expectedQueries := []string{
+		`*[_type == "movie"] | order(_id asc) [0...2]`,
+		`*[_type == "movie"] | order(_id asc) [2...4]`,
+	}
+	if strings.Join(queries, "\n") != strings.Join(expectedQueries, "\n") {
+		t.Errorf("Expected queries %v, got %v", expectedQueries, queries)
+	}
+}
+
+func TestDocumentsService_QueryStream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ms":12,"query":"*[_type == \"movie\"]","result":[{"_id":"a","cast":[{"name":"x"}]},{"_id":"b"},{"_id":"c"}],"syncTags":["s1"]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var ids []string
+	err := client.Documents.QueryStream(context.Background(), "test-project", "production", &QueryRequest{Query: `*[_type == "movie"]`}, func(doc Document) error {
+		ids = append(ids, doc.Id())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("Expected IDs a,b,c, got %v", ids)
+	}
+}
+
+func TestDocumentsService_QueryStream_StopsOnError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"_id":"a"},{"_id":"b"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	stop := errors.New("stop")
+	calls := 0
+	err := client.Documents.QueryStream(context.Background(), "test-project", "production", &QueryRequest{Query: `*`}, func(doc Document) error {
+		calls++
+		return stop
+	})
+	if err != stop {
+		t.Errorf("Expected callback error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 callback invocation, got %d", calls)
+	}
+}
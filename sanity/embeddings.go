@@ -0,0 +1,176 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// EmbeddingsService is a client for the Sanity Embeddings Index API, which
+// provides semantic search over the documents of a dataset.
+//
+// Refer to https://www.sanity.io/docs/embeddings-index-api-overview for more
+// information.
+type EmbeddingsService service
+
+// EmbeddingsQueryRequest describes a semantic search against an embeddings
+// index.
+type EmbeddingsQueryRequest struct {
+	// Query is the text to search for.
+	Query string `json:"query"`
+
+	// MaxResults is the maximum number of results to return. Defaults to 10.
+	MaxResults int `json:"maxResults,omitempty"`
+
+	// Types limits the results to documents of the types.
+	Types []string `json:"-"`
+}
+
+// An EmbeddingsResult is a document matched by a semantic search.
+type EmbeddingsResult struct {
+	// Score is the similarity of the document to the query. Higher scores are
+	// better matches.
+	Score float64 `json:"score"`
+
+	// Value identifies the matched document.
+	Value struct {
+		// DocumentId is the identifier of the document.
+		DocumentId string `json:"documentId"`
+
+		// Type is the type of the document.
+		Type string `json:"type"`
+	} `json:"value"`
+}
+
+// Query searches the embeddings index for the documents most similar to the
+// text of the request, with the best match first.
+func (s *EmbeddingsService) Query(ctx context.Context, projectId, dataset, indexName string, r *EmbeddingsQueryRequest) ([]EmbeddingsResult, error) {
+	if r.Query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	url := fmt.Sprintf("%s/embeddings-index/query/%s/%s", s.client.projectBaseURL(projectId), dataset, indexName)
+
+	type filter struct {
+		Type []string `json:"type,omitempty"`
+	}
+	type request struct {
+		*EmbeddingsQueryRequest
+		Filter *filter `json:"filter,omitempty"`
+	}
+
+	req := &request{EmbeddingsQueryRequest: r}
+	if len(r.Types) > 0 {
+		req.Filter = &filter{Type: r.Types}
+	}
+
+	var results []EmbeddingsResult
+	err := do(ctx, s.client.client, url, http.MethodPost, req, &results)
+
+	return results, err
+}
+
+const (
+	EmbeddingsIndexStatusInitializing = "initializing"
+	EmbeddingsIndexStatusActive       = "active"
+	EmbeddingsIndexStatusPaused       = "paused"
+)
+
+// An EmbeddingsIndex is an index of embeddings for the documents of a dataset.
+type EmbeddingsIndex struct {
+	// IndexName is the name of the index.
+	IndexName string `json:"indexName"`
+
+	// ProjectId is the identifier of the project of the dataset.
+	ProjectId string `json:"projectId"`
+
+	// Dataset is the dataset the index is built from.
+	Dataset string `json:"dataset"`
+
+	// Status is the status of the index. Valid values include the
+	// `EmbeddingsIndexStatus*` constants in this package.
+	Status string `json:"status"`
+
+	// StartDocumentCount is the number of documents to index when the build
+	// of the index started.
+	StartDocumentCount int `json:"startDocumentCount"`
+
+	// RemainingDocumentCount is the number of documents that are yet to be
+	// indexed.
+	RemainingDocumentCount int `json:"remainingDocumentCount"`
+
+	// IndexedDocumentCount is the number of documents that are indexed.
+	IndexedDocumentCount int `json:"indexedDocumentCount"`
+
+	// FailedDocumentCount is the number of documents that could not be
+	// indexed.
+	FailedDocumentCount int `json:"failedDocumentCount"`
+
+	// CreatedAt is the time the index was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is the time the index was last updated.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Ready reports whether the index is active and all documents are indexed.
+func (i *EmbeddingsIndex) Ready() bool {
+	return i.Status == EmbeddingsIndexStatusActive && i.RemainingDocumentCount == 0
+}
+
+// GetIndex fetches the status of an embeddings index.
+func (s *EmbeddingsService) GetIndex(ctx context.Context, projectId, dataset, indexName string) (*EmbeddingsIndex, error) {
+	url := fmt.Sprintf("%s/embeddings-index/%s/%s", s.client.projectBaseURL(projectId), dataset, indexName)
+
+	var index EmbeddingsIndex
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &index)
+
+	return &index, err
+}
+
+type WaitForIndexReadyRequest struct {
+	// PollInterval is the time between checks of the index status. Defaults to
+	// 5 seconds.
+	PollInterval time.Duration
+
+	// Timeout is the maximum time to wait for the index to be ready. There is
+	// no timeout if it is zero, other than the deadline of the context.
+	Timeout time.Duration
+}
+
+// WaitForIndexReady polls the embeddings index until it is ready, and returns
+// the ready index. Indexes are built asynchronously, so a new index cannot be
+// queried until it is ready. An error is returned if the index does not become
+// ready within the timeout.
+func (s *EmbeddingsService) WaitForIndexReady(ctx context.Context, projectId, dataset, indexName string, r *WaitForIndexReadyRequest) (*EmbeddingsIndex, error) {
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		index, err := s.GetIndex(ctx, projectId, dataset, indexName)
+		if err != nil {
+			return nil, err
+		}
+		if index.Ready() {
+			return index, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return index, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
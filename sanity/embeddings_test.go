@@ -0,0 +1,99 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEmbeddingsService_Query(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/embeddings-index/query/production/articles" {
+			t.Errorf("Expected /embeddings-index/query/production/articles path, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		filter, _ := body["filter"].(map[string]any)
+		if body["query"] != "winter sports" || body["maxResults"] != float64(5) || len(filter["type"].([]any)) != 1 {
+			t.Errorf("Unexpected body %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"score":0.91,"value":{"documentId":"post_1","type":"post"}},{"score":0.72,"value":{"documentId":"post_2","type":"post"}}]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	results, err := client.Embeddings.Query(context.Background(), "test-project", "production", "articles", &EmbeddingsQueryRequest{
+		Query:      "winter sports",
+		MaxResults: 5,
+		Types:      []string{"post"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(results) != 2 || results[0].Value.DocumentId != "post_1" || results[0].Score != 0.91 {
+		t.Errorf("Unexpected results %+v", results)
+	}
+}
+
+func TestEmbeddingsService_WaitForIndexReady(t *testing.T) {
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings-index/production/articles" {
+			t.Errorf("Expected /embeddings-index/production/articles path, got %s", r.URL.Path)
+		}
+		polls++
+
+		w.Header().Set("Content-Type", "application/json")
+		if polls < 3 {
+			w.Write([]byte(`{"indexName":"articles","status":"active","startDocumentCount":10,"remainingDocumentCount":4}`))
+			return
+		}
+		w.Write([]byte(`{"indexName":"articles","status":"active","startDocumentCount":10,"remainingDocumentCount":0,"indexedDocumentCount":10}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	index, err := client.Embeddings.WaitForIndexReady(context.Background(), "test-project", "production", "articles", &WaitForIndexReadyRequest{
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if polls != 3 || index.IndexedDocumentCount != 10 {
+		t.Errorf("Expected a ready index after 3 polls, got %+v after %d polls", index, polls)
+	}
+}
+
+func TestEmbeddingsService_WaitForIndexReady_Timeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"indexName":"articles","status":"initializing","remainingDocumentCount":10}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	_, err := client.Embeddings.WaitForIndexReady(context.Background(), "test-project", "production", "articles", &WaitForIndexReadyRequest{
+		PollInterval: time.Millisecond,
+		Timeout:      20 * time.Millisecond,
+	})
+	if err == nil {
+		t.Error("Expected a timeout error")
+	}
+}
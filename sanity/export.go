@@ -0,0 +1,387 @@
+package sanity
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// ExportService is a client for the Sanity Export API.
+//
+// Refer to https://www.sanity.io/docs/export for more information.
+type ExportService service
+
+// ExportRequest describes the documents to export.
+type ExportRequest struct {
+	// Types restricts the export to documents of the specified types. All
+	// documents are exported if it is empty.
+	Types []string
+
+	// ExcludeDrafts excludes draft documents, i.e., documents with identifiers
+	// prefixed with `drafts.`.
+	ExcludeDrafts bool
+
+	// ExcludeSystemDocuments excludes system documents, i.e., documents with
+	// identifiers prefixed with `_.`, such as permission groups.
+	ExcludeSystemDocuments bool
+
+	// UpdatedAfter restricts the export to documents updated after the time,
+	// e.g., the time of the previous export, to produce incremental backups.
+	// The Export API cannot filter by time, so the documents are fetched with
+	// a query instead, which is filtered by the API so that only the updated
+	// documents are transferred.
+	//
+	// Deletions are not captured by an incremental export, as deleted
+	// documents no longer match the query. A full export is needed to detect
+	// them.
+	UpdatedAfter time.Time
+}
+
+// updatedQuery returns the query that fetches the documents updated after
+// UpdatedAfter, with all the filters of the request applied by the API.
+func (r *ExportRequest) updatedQuery() *QueryRequest {
+	filters := []string{"_updatedAt > $since"}
+	params := map[string]any{"since": r.UpdatedAfter.UTC().Format(time.RFC3339Nano)}
+	if len(r.Types) > 0 {
+		filters = append(filters, "_type in $types")
+		params["types"] = r.Types
+	}
+	if r.ExcludeDrafts {
+		filters = append(filters, `!(_id in path("drafts.**"))`)
+	}
+	if r.ExcludeSystemDocuments {
+		filters = append(filters, `!(_id in path("_.**"))`)
+	}
+
+	return &QueryRequest{
+		Query:       "*[" + strings.Join(filters, " && ") + "]",
+		Params:      params,
+		Perspective: PerspectiveRaw,
+	}
+}
+
+// includes reports whether the ndjson line holding a document passes the
+// filters that are applied on the client. Lines that cannot be decoded are
+// included, so that the error is reported by the consumer of the stream.
+func (r *ExportRequest) includes(line []byte) bool {
+	var doc struct {
+		Id string `json:"_id"`
+	}
+	if err := json.Unmarshal(line, &doc); err != nil {
+		return true
+	}
+
+	if r.ExcludeDrafts && strings.HasPrefix(doc.Id, "drafts.") {
+		return false
+	}
+	if r.ExcludeSystemDocuments && strings.HasPrefix(doc.Id, "_.") {
+		return false
+	}
+
+	return true
+}
+
+// Stream exports the documents in the dataset that match the request and
+// returns them as an ndjson stream, one document per line. The caller is
+// responsible for closing the stream.
+//
+// Types are filtered by the API, while the other filters are applied to the
+// stream as it is read. Incremental exports are filtered by the API entirely.
+func (s *ExportService) Stream(ctx context.Context, projectId, dataset string, r *ExportRequest) (io.ReadCloser, error) {
+	if !r.UpdatedAfter.IsZero() {
+		return s.streamUpdated(ctx, projectId, dataset, r), nil
+	}
+
+	params := url.Values{}
+	if len(r.Types) > 0 {
+		params.Set("types", strings.Join(r.Types, ","))
+	}
+
+	url := fmt.Sprintf("%s/data/export/%s", s.client.projectBaseURL(projectId), dataset)
+	if len(params) > 0 {
+		url += "?" + params.Encode()
+	}
+
+	stream, err := openStream(ctx, s.client.client, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !r.ExcludeDrafts && !r.ExcludeSystemDocuments {
+		return stream, nil
+	}
+
+	return &exportFilter{Closer: stream, lines: bufio.NewReader(stream), request: r}, nil
+}
+
+// streamUpdated returns the documents of an incremental export as an ndjson
+// stream. Errors of the query are returned when the stream is read.
+func (s *ExportService) streamUpdated(ctx context.Context, projectId, dataset string, r *ExportRequest) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		enc := json.NewEncoder(pw)
+		err := (*DocumentsService)(s).QueryStream(ctx, projectId, dataset, r.updatedQuery(), func(doc Document) error {
+			return enc.Encode(doc)
+		})
+		pw.CloseWithError(err)
+	}()
+
+	return pr
+}
+
+// exportFilter is an ndjson stream that skips the lines of documents excluded
+// by an export request.
+type exportFilter struct {
+	io.Closer
+	lines   *bufio.Reader
+	request *ExportRequest
+	line    []byte
+	err     error
+}
+
+func (f *exportFilter) Read(p []byte) (int, error) {
+	for len(f.line) == 0 {
+		if f.err != nil {
+			return 0, f.err
+		}
+
+		line, err := f.lines.ReadBytes('\n')
+		f.err = err
+		if len(line) > 0 && f.request.includes(line) {
+			f.line = line
+		}
+	}
+
+	n := copy(p, f.line)
+	f.line = f.line[n:]
+
+	return n, nil
+}
+
+// Documents exports the documents in the dataset that match the request and
+// calls `fn` for each document as it is decoded from the stream.
+//
+// Decoding stops at the first error returned by `fn`, and that error is
+// returned to the caller.
+func (s *ExportService) Documents(ctx context.Context, projectId, dataset string, r *ExportRequest, fn func(Document) error) error {
+	if !r.UpdatedAfter.IsZero() {
+		return (*DocumentsService)(s).QueryStream(ctx, projectId, dataset, r.updatedQuery(), fn)
+	}
+
+	stream, err := s.Stream(ctx, projectId, dataset, r)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	dec := json.NewDecoder(stream)
+	for {
+		var doc Document
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+}
+
+// Archive exports the dataset, including the binary content of its assets, as
+// a gzipped tarball written to `w`.
+//
+// The archive has the same layout as the archives produced by the Sanity CLI
+// and can be imported by it. The documents are stored in `data.ndjson`, with
+// references to assets replaced by `_sanityAsset` attributes that point to the
+// asset files in the `images` and `files` directories. The asset documents are
+// stored in `assets.json`.
+//
+// When the request is restricted to specific types, the asset documents are
+// exported as well, so that the archive holds the assets the documents
+// reference. Asset documents are subject to the other filters, so an
+// incremental archive holds only the assets uploaded since the previous export.
+//
+// Assets are downloaded to temporary files while the archive is written, so
+// the memory use does not grow with the size of the dataset.
+func (s *ExportService) Archive(ctx context.Context, projectId, dataset string, r *ExportRequest, w io.Writer) error {
+	data, err := os.CreateTemp("", "sanity-export-*.ndjson")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(data.Name())
+	defer data.Close()
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	root := dataset + "-export"
+
+	assets := map[string]Document{}
+	enc := json.NewEncoder(data)
+	request := *r
+	if len(request.Types) > 0 {
+		request.Types = append(request.Types[:len(request.Types):len(request.Types)],
+			assetDocumentType(assetTypeImage), assetDocumentType(assetTypeFile))
+	}
+
+	err = s.Documents(ctx, projectId, dataset, &request, func(doc Document) error {
+		if kind := assetKind(doc.Type()); kind != "" {
+			assets[doc.Id()] = doc
+			return s.archiveAsset(ctx, tw, path.Join(root, assetPath(doc.Id())), doc)
+		}
+
+		return enc.Encode(rewriteAssetReferences(doc))
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := data.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, path.Join(root, "data.ndjson"), data); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(assets)
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, path.Join(root, "assets.json"), strings.NewReader(string(b))); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// archiveAsset downloads the content of the asset document and writes it to
+// the archive with the specified name.
+func (s *ExportService) archiveAsset(ctx context.Context, tw *tar.Writer, name string, doc Document) error {
+	url, _ := doc["url"].(string)
+	if url == "" {
+		return fmt.Errorf("asset %s has no url", doc.Id())
+	}
+
+	body, err := openStream(ctx, s.client.client, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("downloading asset %s: %w", doc.Id(), err)
+	}
+	defer body.Close()
+
+	tmp, err := os.CreateTemp("", "sanity-asset-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		return fmt.Errorf("downloading asset %s: %w", doc.Id(), err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return writeTarFile(tw, name, tmp)
+}
+
+// writeTarFile writes the content of `r` to the archive as a regular file.
+func writeTarFile(tw *tar.Writer, name string, r io.Reader) error {
+	var size int64
+	switch r := r.(type) {
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil {
+			return err
+		}
+		size = info.Size()
+	case *strings.Reader:
+		size = r.Size()
+	default:
+		return fmt.Errorf("unsupported reader %T", r)
+	}
+
+	err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, r)
+	return err
+}
+
+// assetKind returns `image` or `file` for the document types of assets, and
+// an empty string for any other document type.
+func assetKind(docType string) string {
+	switch docType {
+	case "sanity.imageAsset":
+		return assetTypeImage
+	case "sanity.fileAsset":
+		return assetTypeFile
+	}
+
+	return ""
+}
+
+// assetPath returns the path of an asset in an archive, derived from the
+// asset document identifier. For example, the asset `image-abc-10x20-png` is
+// stored at `images/abc-10x20.png`.
+func assetPath(assetId string) string {
+	kind, rest, _ := strings.Cut(assetId, "-")
+	if i := strings.LastIndex(rest, "-"); i >= 0 {
+		rest = rest[:i] + "." + rest[i+1:]
+	}
+
+	return kind + "s/" + rest
+}
+
+// rewriteAssetReferences replaces the asset references in the value with
+// `_sanityAsset` attributes that point to the asset files in an archive.
+func rewriteAssetReferences(v any) any {
+	switch v := v.(type) {
+	case Document:
+		return Document(rewriteAssetReferences(map[string]any(v)).(map[string]any))
+	case map[string]any:
+		if asset, ok := v["asset"].(map[string]any); ok {
+			ref, _ := asset["_ref"].(string)
+			if strings.HasPrefix(ref, "image-") || strings.HasPrefix(ref, "file-") {
+				kind, _, _ := strings.Cut(ref, "-")
+				delete(v, "asset")
+				v["_sanityAsset"] = fmt.Sprintf("%s@file://./%s", kind, assetPath(ref))
+			}
+		}
+		for key, value := range v {
+			v[key] = rewriteAssetReferences(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = rewriteAssetReferences(value)
+		}
+		return v
+	}
+
+	return v
+}
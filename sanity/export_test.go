@@ -0,0 +1,252 @@
+package sanity
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testExport = `{"_id":"a","_type":"movie"}
+{"_id":"b","_type":"person"}
+{"_id":"drafts.a","_type":"movie"}
+{"_id":"_.groups.public","_type":"system.group"}
+`
+
+func TestExportService_Stream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/export/production" {
+			t.Errorf("Expected /data/export/production path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(testExport))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	stream, err := client.Export.Stream(context.Background(), "test-project", "production", &ExportRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer stream.Close()
+
+	b, _ := io.ReadAll(stream)
+	if string(b) != testExport {
+		t.Errorf("Unexpected export %s", b)
+	}
+}
+
+func TestExportService_Documents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(testExport))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var ids []string
+	err := client.Export.Documents(context.Background(), "test-project", "production", &ExportRequest{}, func(doc Document) error {
+		ids = append(ids, doc.Id())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(ids, ",") != "a,b,drafts.a,_.groups.public" {
+		t.Errorf("Unexpected documents %v", ids)
+	}
+}
+
+func TestExportService_Stream_Filters(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("types") != "movie,person" {
+			t.Errorf("Expected types 'movie,person', got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(testExport))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	stream, err := client.Export.Stream(context.Background(), "test-project", "production", &ExportRequest{
+		Types:                  []string{"movie", "person"},
+		ExcludeDrafts:          true,
+		ExcludeSystemDocuments: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer stream.Close()
+
+	b, _ := io.ReadAll(stream)
+	expected := `{"_id":"a","_type":"movie"}` + "\n" + `{"_id":"b","_type":"person"}` + "\n"
+	if string(b) != expected {
+		t.Errorf("Expected export %s, got %s", expected, b)
+	}
+}
+
+func TestExportService_Archive_MaxConcurrency(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/export/production":
+			w.Write([]byte(`{"_id":"image-abc-1x1-png","_type":"sanity.imageAsset","url":"` + ts.URL + `/images/abc-1x1.png"}` + "\n"))
+		case "/images/abc-1x1.png":
+			w.Write([]byte("png bytes"))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithMaxConcurrency(1))
+	client.testProjectBaseURL = ts.URL
+
+	// The assets are downloaded while the export stream is read, which must
+	// not wait for the slot of the stream.
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := client.Export.Archive(ctx, "test-project", "production", &ExportRequest{}, io.Discard); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestExportService_Documents_UpdatedAfter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/query/production" || r.URL.Query().Get("perspective") != PerspectiveRaw {
+			t.Errorf("Expected a raw query, got %s", r.URL)
+		}
+
+		var req QueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		expected := `*[_updatedAt > $since && _type in $types && !(_id in path("drafts.**"))]`
+		if req.Query != expected {
+			t.Errorf("Expected query %s, got %s", expected, req.Query)
+		}
+		if req.Params["since"] != "2024-02-01T00:00:00Z" {
+			t.Errorf("Expected $since to be sent, got %v", req.Params)
+		}
+
+		w.Write([]byte(`{"result":[{"_id":"b","_type":"movie","_updatedAt":"2024-03-01T00:00:00Z"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	r := &ExportRequest{
+		Types:         []string{"movie"},
+		ExcludeDrafts: true,
+		UpdatedAfter:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var ids []string
+	err := client.Export.Documents(context.Background(), "test-project", "production", r, func(doc Document) error {
+		ids = append(ids, doc.Id())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(ids, ",") != "b" {
+		t.Errorf("Expected only document b, got %v", ids)
+	}
+
+	stream, err := client.Export.Stream(context.Background(), "test-project", "production", r)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer stream.Close()
+
+	b, _ := io.ReadAll(stream)
+	if !strings.HasPrefix(string(b), `{"_id":"b"`) || strings.Count(string(b), "\n") != 1 {
+		t.Errorf("Expected document b as ndjson, got %s", b)
+	}
+}
+
+func TestExportService_Archive(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/export/production":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Write([]byte(`{"_id":"a","_type":"movie","poster":{"_type":"image","asset":{"_ref":"image-abc-10x20-png","_type":"reference"}}}` + "\n"))
+			w.Write([]byte(`{"_id":"image-abc-10x20-png","_type":"sanity.imageAsset","url":"` + ts.URL + `/images/abc-10x20.png"}` + "\n"))
+		case "/images/abc-10x20.png":
+			w.Write([]byte("png bytes"))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var buf bytes.Buffer
+	if err := client.Export.Archive(context.Background(), "test-project", "production", &ExportRequest{}, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("Expected a gzip stream, got %v", err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read archive: %v", err)
+		}
+		b, _ := io.ReadAll(tr)
+		files[hdr.Name] = string(b)
+	}
+
+	if files["production-export/images/abc-10x20.png"] != "png bytes" {
+		t.Errorf("Expected the image to be archived, got files %v", files)
+	}
+
+	var doc Document
+	if err := json.Unmarshal([]byte(files["production-export/data.ndjson"]), &doc); err != nil {
+		t.Fatalf("Failed to decode data.ndjson: %v", err)
+	}
+	poster := doc["poster"].(map[string]any)
+	if poster["_sanityAsset"] != "image@file://./images/abc-10x20.png" {
+		t.Errorf("Expected the asset reference to be rewritten, got %v", poster)
+	}
+	if !strings.Contains(files["production-export/assets.json"], `"image-abc-10x20-png"`) {
+		t.Errorf("Expected the asset document in assets.json, got %s", files["production-export/assets.json"])
+	}
+}
+
+func TestAssetPath(t *testing.T) {
+	tests := map[string]string{
+		"image-abc-10x20-png": "images/abc-10x20.png",
+		"file-def-pdf":        "files/def.pdf",
+	}
+
+	for assetId, expected := range tests {
+		if actual := assetPath(assetId); actual != expected {
+			t.Errorf("Expected path '%s' for %s, got '%s'", expected, assetId, actual)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphQLService is a client for the Sanity GraphQL API and the management of
+// its deployments.
+//
+// Refer to https://www.sanity.io/docs/graphql for more information.
+type GraphQLService service
+
+const (
+	GraphQLGeneration1 = "gen1"
+	GraphQLGeneration2 = "gen2"
+	GraphQLGeneration3 = "gen3"
+)
+
+// A GraphQLDeployment is a GraphQL API deployed for a dataset.
+type GraphQLDeployment struct {
+	// ProjectId is the identifier of the project the API belongs to.
+	ProjectId string `json:"projectId,omitempty"`
+
+	// Dataset is the dataset the API serves.
+	Dataset string `json:"dataset"`
+
+	// Tag is the tag of the deployment. The default tag is `default`.
+	Tag string `json:"tag"`
+
+	// Generation is the generation of the schema. Valid values are represented
+	// as the `GraphQLGeneration*` constants in this package.
+	Generation string `json:"generation,omitempty"`
+
+	// PlaygroundEnabled indicates whether the GraphQL playground is served at
+	// the API URL.
+	PlaygroundEnabled bool `json:"playgroundEnabled"`
+}
+
+// ListDeployments fetches and returns the GraphQL APIs deployed for the
+// project.
+func (s *GraphQLService) ListDeployments(ctx context.Context, projectId string) ([]GraphQLDeployment, error) {
+	url := fmt.Sprintf("%s/apis/graphql", s.client.projectBaseURL(projectId))
+
+	var deployments []GraphQLDeployment
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &deployments)
+
+	return deployments, err
+}
+
+// DeployGraphQLRequest describes a GraphQL API to deploy.
+type DeployGraphQLRequest struct {
+	// Schema is the schema definition of the API, in the format produced by
+	// `sanity graphql deploy --dry-run`.
+	Schema any `json:"schema"`
+
+	// Generation is the generation of the schema. Valid values are represented
+	// as the `GraphQLGeneration*` constants in this package. Defaults to
+	// GraphQLGeneration3.
+	Generation string `json:"generation,omitempty"`
+
+	// EnablePlayground indicates whether the GraphQL playground is served at
+	// the API URL.
+	EnablePlayground bool `json:"enablePlayground"`
+
+	// NonNullDocumentFields marks the system fields of documents, such as
+	// `_id` and `_type`, as non-nullable in the schema.
+	NonNullDocumentFields bool `json:"nonNullDocumentFields,omitempty"`
+}
+
+type DeployGraphQLResponse struct {
+	// Location is the path of the deployed API.
+	Location string `json:"location"`
+}
+
+// Deploy deploys a GraphQL API for the dataset with the specified tag,
+// replacing any existing deployment with the same tag.
+func (s *GraphQLService) Deploy(ctx context.Context, projectId, dataset, tag string, r *DeployGraphQLRequest) (*DeployGraphQLResponse, error) {
+	if r.Schema == nil {
+		return nil, errors.New("schema is required")
+	}
+
+	request := *r
+	if request.Generation == "" {
+		request.Generation = GraphQLGeneration3
+	}
+
+	url := fmt.Sprintf("%s/apis/graphql/%s/%s", s.client.projectBaseURL(projectId), dataset, tag)
+
+	var response DeployGraphQLResponse
+	err := do(ctx, s.client.client, url, http.MethodPut, &request, &response)
+
+	return &response, err
+}
+
+// DeleteDeployment deletes the GraphQL API deployed for the dataset with the
+// specified tag.
+func (s *GraphQLService) DeleteDeployment(ctx context.Context, projectId, dataset, tag string) (bool, error) {
+	url := fmt.Sprintf("%s/apis/graphql/%s/%s", s.client.projectBaseURL(projectId), dataset, tag)
+
+	type response struct {
+		Deleted bool `json:"deleted"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodDelete, nil, &resp)
+	return resp.Deleted, err
+}
+
+// GraphQLRequest is a GraphQL query with its variables.
+type GraphQLRequest struct {
+	// Query is the GraphQL query document.
+	Query string `json:"query"`
+
+	// Variables are the values of the variables referenced by the query.
+	Variables map[string]any `json:"variables,omitempty"`
+
+	// OperationName selects the operation to execute if the query document
+	// holds several operations.
+	OperationName string `json:"operationName,omitempty"`
+}
+
+// A GraphQLError is an error reported by the GraphQL API.
+type GraphQLError struct {
+	// Message describes the error.
+	Message string `json:"message"`
+
+	// Path is the path of the field in the result that caused the error, if
+	// any.
+	Path []any `json:"path,omitempty"`
+
+	// Locations are the locations in the query document the error relates
+	// to.
+	Locations []GraphQLErrorLocation `json:"locations,omitempty"`
+}
+
+func (e *GraphQLError) Error() string {
+	return e.Message
+}
+
+// A GraphQLErrorLocation is a position in a GraphQL query document.
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLErrors are the errors reported by the GraphQL API for a query.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// graphQLStatusError is returned when the GraphQL API rejects a query with an
+// unsuccessful status code, e.g., a query that does not validate. It is found
+// as GraphQLErrors by errors.As, and unwraps to the *APIError describing the
+// response.
+type graphQLStatusError struct {
+	errs   GraphQLErrors
+	apiErr *APIError
+}
+
+func (e *graphQLStatusError) Error() string {
+	return e.errs.Error()
+}
+
+func (e *graphQLStatusError) Unwrap() error {
+	return e.apiErr
+}
+
+// As sets the target to the errors of the response if it is a *GraphQLErrors.
+func (e *graphQLStatusError) As(target any) bool {
+	t, ok := target.(*GraphQLErrors)
+	if ok {
+		*t = e.errs
+	}
+
+	return ok
+}
+
+// Query executes a GraphQL query against the API deployed for the dataset with
+// the specified tag, and decodes the `data` of the response into `result`. The
+// tag defaults to `default` if it is blank.
+//
+// If the API reports errors, they are returned as GraphQLErrors. Any partial
+// data in the response is still decoded into `result`. Queries that fail to
+// parse or validate are rejected with HTTP 400, in which case the *APIError
+// describing the response is also available with errors.As.
+func (s *GraphQLService) Query(ctx context.Context, projectId, dataset, tag string, r *GraphQLRequest, result any) error {
+	if tag == "" {
+		tag = "default"
+	}
+
+	url := fmt.Sprintf("%s/graphql/%s/%s", s.client.projectBaseURL(projectId), dataset, tag)
+
+	type response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+
+	var resp response
+	if err := do(ctx, s.client.client, url, http.MethodPost, r, &resp); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && json.Unmarshal(apiErr.Body, &resp) == nil && len(resp.Errors) > 0 {
+			return &graphQLStatusError{errs: resp.Errors, apiErr: apiErr}
+		}
+		return err
+	}
+
+	if len(resp.Data) > 0 && string(resp.Data) != "null" {
+		if err := json.Unmarshal(resp.Data, result); err != nil {
+			return err
+		}
+	}
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGraphQLService_ListDeployments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/apis/graphql" {
+			t.Errorf("Expected /apis/graphql path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"projectId":"test-project","dataset":"production","tag":"default","generation":"gen3","playgroundEnabled":true}]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	deployments, err := client.GraphQL.ListDeployments(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(deployments) != 1 {
+		t.Fatalf("Expected 1 deployment, got %d", len(deployments))
+	}
+	if deployments[0].Dataset != "production" || deployments[0].Tag != "default" || !deployments[0].PlaygroundEnabled {
+		t.Errorf("Unexpected deployment %+v", deployments[0])
+	}
+}
+
+func TestGraphQLService_Deploy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/apis/graphql/production/preview" {
+			t.Errorf("Expected /apis/graphql/production/preview path, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if body["generation"] != GraphQLGeneration3 || body["enablePlayground"] != true || body["schema"] == nil {
+			t.Errorf("Unexpected body %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"location":"/v1/graphql/production/preview"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	resp, err := client.GraphQL.Deploy(context.Background(), "test-project", "production", "preview", &DeployGraphQLRequest{
+		Schema:           map[string]any{"types": []any{}},
+		EnablePlayground: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Location != "/v1/graphql/production/preview" {
+		t.Errorf("Expected location '/v1/graphql/production/preview', got '%s'", resp.Location)
+	}
+}
+
+func TestGraphQLService_DeleteDeployment(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/apis/graphql/production/preview" {
+			t.Errorf("Expected /apis/graphql/production/preview path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"deleted":true}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	deleted, err := client.GraphQL.DeleteDeployment(context.Background(), "test-project", "production", "preview")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !deleted {
+		t.Error("Expected the deployment to be deleted")
+	}
+}
+
+func TestGraphQLService_Query(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/graphql/production/default" {
+			t.Errorf("Expected /graphql/production/default path, got %s", r.URL.Path)
+		}
+
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if req.Variables["id"] != "movie_1" {
+			t.Errorf("Unexpected variables %v", req.Variables)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"Movie":{"title":"Alien"}}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var result struct {
+		Movie struct {
+			Title string `json:"title"`
+		} `json:"Movie"`
+	}
+	err := client.GraphQL.Query(context.Background(), "test-project", "production", "", &GraphQLRequest{
+		Query:     `query($id: ID!) { Movie(id: $id) { title } }`,
+		Variables: map[string]any{"id": "movie_1"},
+	}, &result)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Movie.Title != "Alien" {
+		t.Errorf("Expected title 'Alien', got '%s'", result.Movie.Title)
+	}
+}
+
+func TestGraphQLService_Query_Errors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":null,"errors":[{"message":"Cannot query field \"rating\"","locations":[{"line":1,"column":3}]}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var result map[string]any
+	err := client.GraphQL.Query(context.Background(), "test-project", "production", "default", &GraphQLRequest{Query: "{ rating }"}, &result)
+
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) {
+		t.Fatalf("Expected GraphQLErrors, got %v", err)
+	}
+	if len(gqlErrs) != 1 || gqlErrs[0].Locations[0].Line != 1 {
+		t.Errorf("Unexpected errors %+v", gqlErrs)
+	}
+}
+
+func TestGraphQLService_Query_BadRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":[{"message":"Syntax Error: Expected Name, found <EOF>.","locations":[{"line":1,"column":2}]}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var result map[string]any
+	err := client.GraphQL.Query(context.Background(), "test-project", "production", "default", &GraphQLRequest{Query: "{"}, &result)
+
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) {
+		t.Fatalf("Expected GraphQLErrors, got %v", err)
+	}
+	if len(gqlErrs) != 1 || gqlErrs[0].Locations[0].Column != 2 {
+		t.Errorf("Unexpected errors %+v", gqlErrs)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected an APIError with status 400, got %v", err)
+	}
+}
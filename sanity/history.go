@@ -0,0 +1,218 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryService is a client for the Sanity History API.
+//
+// Refer to https://www.sanity.io/docs/history-api for more information.
+type HistoryService service
+
+// GetRevisionRequest identifies a revision of a document, either by its
+// revision identifier or by a point in time.
+type GetRevisionRequest struct {
+	// Revision is the identifier of the revision, i.e., the `_rev` attribute of
+	// the document at the time. It takes precedence over Time.
+	Revision string
+
+	// Time selects the revision of the document as it was at the time.
+	Time time.Time
+}
+
+// GetRevision fetches a document as it was at a specific revision or point in
+// time. A nil Document is returned if the document did not exist at the time.
+func (s *HistoryService) GetRevision(ctx context.Context, projectId, dataset, docId string, r *GetRevisionRequest) (Document, error) {
+	params := url.Values{}
+	switch {
+	case r.Revision != "":
+		params.Set("revision", r.Revision)
+	case !r.Time.IsZero():
+		params.Set("time", r.Time.UTC().Format(time.RFC3339Nano))
+	default:
+		return nil, errors.New("a revision or time is required")
+	}
+
+	url := fmt.Sprintf("%s/data/history/%s/documents/%s?%s", s.client.projectBaseURL(projectId), dataset, docId, params.Encode())
+
+	type response struct {
+		Documents []Document `json:"documents"`
+	}
+
+	var resp response
+	if err := do(ctx, s.client.client, url, http.MethodGet, nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Documents) == 0 {
+		return nil, nil
+	}
+
+	return resp.Documents[0], nil
+}
+
+// Restore writes a document back as it was at a specific revision or point in
+// time, replacing its current content, and returns the new revision
+// identifier of the document.
+//
+// The restore is a regular `createOrReplace` mutation, so it is recorded as a
+// new transaction and can itself be undone.
+func (s *HistoryService) Restore(ctx context.Context, projectId, dataset, docId string, r *GetRevisionRequest) (string, error) {
+	doc, err := s.GetRevision(ctx, projectId, dataset, docId, r)
+	if err != nil {
+		return "", err
+	}
+	if doc == nil {
+		return "", fmt.Errorf("document %s did not exist at the revision", docId)
+	}
+
+	// The system attributes are assigned by Sanity when the document is
+	// written.
+	delete(doc, "_rev")
+	delete(doc, "_updatedAt")
+
+	resp, err := s.client.Documents.Mutate(ctx, projectId, dataset, &MutateRequest{
+		Mutations: []Mutation{{CreateOrReplace: doc}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.TransactionId, nil
+}
+
+// ListTransactionsRequest describes filters for listing the transactions that
+// changed a set of documents.
+type ListTransactionsRequest struct {
+	// DocumentIds are the identifiers of the documents. At least one is
+	// required.
+	DocumentIds []string
+
+	// FromTime filters the transactions to those made at or after the time.
+	FromTime time.Time
+
+	// ToTime filters the transactions to those made at or before the time.
+	ToTime time.Time
+
+	// FromTransaction filters the transactions to those made after the
+	// transaction with the identifier, inclusive.
+	FromTransaction string
+
+	// ToTransaction filters the transactions to those made before the
+	// transaction with the identifier, inclusive.
+	ToTransaction string
+
+	// Authors filters the transactions to those made by the users or robots
+	// with the identifiers.
+	Authors []string
+
+	// ExcludeContent omits the mutations from the transactions.
+	ExcludeContent bool
+
+	// Reverse lists the most recent transactions first.
+	Reverse bool
+
+	// Limit is the maximum number of transactions to list. There is no limit if
+	// it is zero.
+	Limit int
+}
+
+// values returns the query parameters for the request.
+func (r *ListTransactionsRequest) values() url.Values {
+	params := url.Values{}
+	if !r.FromTime.IsZero() {
+		params.Set("fromTime", r.FromTime.UTC().Format(time.RFC3339Nano))
+	}
+	if !r.ToTime.IsZero() {
+		params.Set("toTime", r.ToTime.UTC().Format(time.RFC3339Nano))
+	}
+	if r.FromTransaction != "" {
+		params.Set("fromTransaction", r.FromTransaction)
+	}
+	if r.ToTransaction != "" {
+		params.Set("toTransaction", r.ToTransaction)
+	}
+	if len(r.Authors) > 0 {
+		params.Set("authors", strings.Join(r.Authors, ","))
+	}
+	if r.ExcludeContent {
+		params.Set("excludeContent", "true")
+	}
+	if r.Reverse {
+		params.Set("reverse", "true")
+	}
+	if r.Limit > 0 {
+		params.Set("limit", strconv.Itoa(r.Limit))
+	}
+
+	return params
+}
+
+// A Transaction is an entry in the transaction log of a dataset.
+type Transaction struct {
+	// Id is the identifier of the transaction. This is also the revision of
+	// the documents that were changed.
+	Id string `json:"id"`
+
+	// Timestamp is the time the transaction was committed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Author is the identifier of the user or robot that made the transaction.
+	Author string `json:"author"`
+
+	// DocumentIds are the identifiers of the documents changed by the
+	// transaction.
+	DocumentIds []string `json:"documentIDs"`
+
+	// Mutations are the mutations applied by the transaction, in the same
+	// format as they are submitted to the Mutations API. They are omitted if
+	// the transactions were requested with ExcludeContent.
+	Mutations []json.RawMessage `json:"mutations"`
+}
+
+// ListTransactions streams the transactions that changed the documents in the
+// request and calls `fn` for each transaction, oldest first unless Reverse is
+// set.
+//
+// Decoding stops at the first error returned by `fn`, and that error is
+// returned to the caller.
+func (s *HistoryService) ListTransactions(ctx context.Context, projectId, dataset string, r *ListTransactionsRequest, fn func(*Transaction) error) error {
+	if len(r.DocumentIds) == 0 {
+		return errors.New("at least one document id is required")
+	}
+
+	url := fmt.Sprintf("%s/data/history/%s/transactions/%s", s.client.projectBaseURL(projectId), dataset, strings.Join(r.DocumentIds, ","))
+	if params := r.values(); len(params) > 0 {
+		url += "?" + params.Encode()
+	}
+
+	stream, err := openStream(ctx, s.client.client, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	dec := json.NewDecoder(stream)
+	for {
+		var transaction Transaction
+		err := dec.Decode(&transaction)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(&transaction); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHistoryService_GetRevision(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/history/production/documents/movie_1" {
+			t.Errorf("Expected /data/history/production/documents/movie_1 path, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("time") != "2024-01-02T03:04:05Z" {
+			t.Errorf("Expected time '2024-01-02T03:04:05Z', got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"documents":[{"_id":"movie_1","_type":"movie","_rev":"rev-1","title":"Alien"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	doc, err := client.History.GetRevision(context.Background(), "test-project", "production", "movie_1", &GetRevisionRequest{
+		Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if doc.Rev() != "rev-1" || doc["title"] != "Alien" {
+		t.Errorf("Unexpected document %v", doc)
+	}
+}
+
+func TestHistoryService_GetRevision_MissingRevision(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.History.GetRevision(context.Background(), "test-project", "production", "movie_1", &GetRevisionRequest{})
+	if err == nil {
+		t.Error("Expected an error for a missing revision and time")
+	}
+}
+
+func TestHistoryService_ListTransactions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/history/production/transactions/movie_1,movie_2" {
+			t.Errorf("Expected /data/history/production/transactions/movie_1,movie_2 path, got %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("fromTime") != "2024-01-01T00:00:00Z" || query.Get("authors") != "user-1,user-2" || query.Get("excludeContent") != "true" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(`{"id":"tx-1","timestamp":"2024-01-02T00:00:00Z","author":"user-1","documentIDs":["movie_1"]}` + "\n"))
+		w.Write([]byte(`{"id":"tx-2","timestamp":"2024-01-03T00:00:00Z","author":"user-2","documentIDs":["movie_1","movie_2"]}` + "\n"))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var transactions []*Transaction
+	err := client.History.ListTransactions(context.Background(), "test-project", "production", &ListTransactionsRequest{
+		DocumentIds:    []string{"movie_1", "movie_2"},
+		FromTime:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Authors:        []string{"user-1", "user-2"},
+		ExcludeContent: true,
+	}, func(transaction *Transaction) error {
+		transactions = append(transactions, transaction)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(transactions) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(transactions))
+	}
+	if transactions[1].Id != "tx-2" || transactions[1].Author != "user-2" || len(transactions[1].DocumentIds) != 2 {
+		t.Errorf("Unexpected transaction %+v", transactions[1])
+	}
+}
+
+func TestHistoryService_Restore(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/data/history/production/documents/movie_1":
+			if r.URL.Query().Get("revision") != "rev-1" {
+				t.Errorf("Expected revision 'rev-1', got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"documents":[{"_id":"movie_1","_type":"movie","_rev":"rev-1","title":"Alien"}]}`))
+		case "/data/mutate/production":
+			var body struct {
+				Mutations []map[string]map[string]any `json:"mutations"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			doc := body.Mutations[0]["createOrReplace"]
+			if doc["title"] != "Alien" || doc["_rev"] != nil {
+				t.Errorf("Unexpected restored document %v", doc)
+			}
+			w.Write([]byte(`{"transactionId":"rev-3"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	rev, err := client.History.Restore(context.Background(), "test-project", "production", "movie_1", &GetRevisionRequest{Revision: "rev-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rev != "rev-3" {
+		t.Errorf("Expected revision 'rev-3', got '%s'", rev)
+	}
+}
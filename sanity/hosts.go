@@ -0,0 +1,76 @@
+package sanity
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// defaultProjectHost is the pattern of the URLs of the project-scoped APIs.
+const defaultProjectHost = "https://{projectId}.api.sanity.io"
+
+// WithBaseURL sends the requests of the APIs that are not scoped to a project,
+// such as the Projects API, to `baseURL` instead of `https://api.sanity.io`,
+// e.g., to go through a proxy or private gateway. The base URL may include a
+// path, which is prefixed to the paths of the API.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithProjectHost sends the requests of the project-scoped APIs, such as the
+// data APIs and the Webhooks API, to the URL given by `pattern` instead of
+// `https://{projectId}.api.sanity.io`. The `{projectId}` placeholder is
+// replaced with the project of the request, and may appear in the host or the
+// path, e.g.:
+//
+//	sanity.WithProjectHost("https://{projectId}.sanity.example.com")
+//	sanity.WithProjectHost("https://gateway.example.com/sanity/{projectId}")
+func WithProjectHost(pattern string) ClientOption {
+	return func(c *Client) {
+		c.projectHost = newProjectHost(pattern)
+	}
+}
+
+// projectHost builds and recognizes the URLs of the project-scoped APIs.
+type projectHost struct {
+	pattern string
+
+	// match matches the URLs built from the pattern, capturing the project
+	// and the path that follows the pattern.
+	match *regexp.Regexp
+}
+
+func newProjectHost(pattern string) projectHost {
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	expr := regexp.QuoteMeta(pattern)
+	if before, after, ok := strings.Cut(pattern, "{projectId}"); ok {
+		expr = regexp.QuoteMeta(before) + `(?P<projectId>[^./]+)` + regexp.QuoteMeta(after)
+	}
+
+	return projectHost{
+		pattern: pattern,
+		match:   regexp.MustCompile(`^` + expr + `(?P<path>/.*)?$`),
+	}
+}
+
+// url returns the URL of the project-scoped APIs of the project.
+func (h projectHost) url(projectId string) string {
+	return strings.ReplaceAll(h.pattern, "{projectId}", projectId)
+}
+
+// parse returns the project and the API path of a URL built from the pattern,
+// and reports whether the URL was built from the pattern.
+func (h projectHost) parse(u *url.URL) (projectId string, path string, ok bool) {
+	m := h.match.FindStringSubmatch(u.Scheme + "://" + u.Host + u.Path)
+	if m == nil {
+		return "", "", false
+	}
+	if i := h.match.SubexpIndex("projectId"); i >= 0 {
+		projectId = m[i]
+	}
+
+	return projectId, m[h.match.SubexpIndex("path")], true
+}
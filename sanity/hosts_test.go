@@ -0,0 +1,65 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_CustomHosts(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient,
+		WithBaseURL(ts.URL+"/management/"),
+		WithProjectHost(ts.URL+"/projects/{projectId}"),
+	)
+	ctx := context.Background()
+
+	client.Projects.ListDatasets(ctx, "test-project")
+	client.Webhooks.List(ctx, "test-project")
+	client.Documents.Query(ctx, "test-project", "production", &QueryRequest{Query: "*"}, nil)
+
+	expected := []string{
+		"/management/v2021-06-07/projects/test-project/datasets",
+		"/projects/test-project/v2025-02-19/hooks/projects/test-project",
+		"/projects/test-project/v2025-02-19/data/query/production",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], paths[i])
+		}
+	}
+}
+
+func TestClient_DescribeRequestWithCustomHosts(t *testing.T) {
+	client := NewClient(nil,
+		WithBaseURL("https://gateway.example.com/sanity"),
+		WithProjectHost("https://{projectId}.sanity.example.com"),
+	)
+
+	tests := []struct {
+		url       string
+		service   string
+		projectId string
+	}{
+		{"https://abc123.sanity.example.com/v2025-02-19/data/query/production", "data", "abc123"},
+		{"https://gateway.example.com/sanity/v2021-06-07/projects/abc123/datasets", "projects", "abc123"},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+		info := client.describeRequest(req)
+		if info.service != tt.service || info.projectId != tt.projectId {
+			t.Errorf("%s: expected %s/%s, got %+v", tt.url, tt.service, tt.projectId, info)
+		}
+	}
+}
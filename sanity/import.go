@@ -0,0 +1,292 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ImportService imports documents into a dataset, in the same way as the
+// `sanity dataset import` command of the Sanity CLI.
+type ImportService service
+
+const (
+	// ImportOperationCreate fails the import if a document already exists.
+	ImportOperationCreate = "create"
+
+	// ImportOperationCreateOrReplace replaces existing documents.
+	ImportOperationCreateOrReplace = "createOrReplace"
+
+	// ImportOperationCreateIfNotExists skips existing documents.
+	ImportOperationCreateIfNotExists = "createIfNotExists"
+)
+
+// ImportRequest describes the documents to import.
+type ImportRequest struct {
+	// Documents is an ndjson stream of documents, one document per line, e.g.,
+	// the `data.ndjson` file of an export archive.
+	Documents io.Reader
+
+	// Assets is the file system that `file://` asset paths are resolved
+	// against, typically the directory an export archive was extracted to. It
+	// is required if the documents reference local asset files.
+	Assets fs.FS
+
+	// Operation is the mutation used to write each document. Valid values are
+	// represented as the `ImportOperation*` constants in this package. Defaults
+	// to ImportOperationCreate.
+	Operation string
+
+	// BatchSize is the maximum number of documents written per transaction.
+	// Defaults to 100.
+	BatchSize int
+
+	// ResumeFrom is the number of documents at the start of the stream to
+	// skip. Set it to the last offset reported to OnCheckpoint to resume an
+	// interrupted import.
+	ResumeFrom int
+
+	// OnDocument is called with the identifier of each document once the
+	// transaction that writes it is committed.
+	OnDocument func(id string)
+
+	// OnCheckpoint is called after each committed transaction with the number
+	// of documents from the start of the stream that have been written. An
+	// error returned by OnCheckpoint stops the import and is returned to the
+	// caller.
+	OnCheckpoint func(offset int) error
+}
+
+// ImportResponse describes the outcome of an import.
+type ImportResponse struct {
+	// Documents is the number of documents written.
+	Documents int
+
+	// Assets is the number of distinct assets uploaded.
+	Assets int
+}
+
+// Import writes the documents in the request to the dataset in batched
+// transactions.
+//
+// Asset references in the documents that were replaced by `_sanityAsset`
+// attributes on export, e.g., `image@file://./images/abc-10x20.png`, are
+// resolved by uploading the referenced file and replacing the attribute with a
+// reference to the new asset. Local files are read from the Assets file
+// system, and `http` and `https` locations are downloaded. Each file is
+// uploaded once, even if it is referenced by several documents. Local files
+// are uploaded with UploadImageIfMissing and UploadFileIfMissing, so re-running
+// an import does not create duplicate assets.
+//
+// A long-running import can be made resumable by persisting the offsets
+// reported to OnCheckpoint and passing the last one as ResumeFrom when the
+// import is restarted with the same documents.
+func (s *ImportService) Import(ctx context.Context, projectId, dataset string, r *ImportRequest) (*ImportResponse, error) {
+	if r.Documents == nil {
+		return nil, errors.New("documents are required")
+	}
+
+	operation := r.Operation
+	if operation == "" {
+		operation = ImportOperationCreate
+	}
+	batchSize := r.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	var response ImportResponse
+	assets := map[string]string{}
+	resolve := func(ref string) (string, error) {
+		if id, ok := assets[ref]; ok {
+			return id, nil
+		}
+
+		id, err := s.uploadAsset(ctx, projectId, dataset, r.Assets, ref)
+		if err != nil {
+			return "", fmt.Errorf("uploading asset %s: %w", ref, err)
+		}
+		assets[ref] = id
+		response.Assets++
+
+		return id, nil
+	}
+
+	var batch []Mutation
+	var ids []string
+	offset := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		_, err := s.client.Documents.Mutate(ctx, projectId, dataset, &MutateRequest{
+			Mutations:  batch,
+			Visibility: VisibilityAsync,
+			ReturnIds:  NewBool(false),
+		})
+		if err != nil {
+			return err
+		}
+		response.Documents += len(batch)
+		if r.OnDocument != nil {
+			for _, id := range ids {
+				r.OnDocument(id)
+			}
+		}
+		batch, ids = nil, nil
+
+		if r.OnCheckpoint != nil {
+			return r.OnCheckpoint(offset)
+		}
+
+		return nil
+	}
+
+	dec := json.NewDecoder(r.Documents)
+	for {
+		var doc Document
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &response, err
+		}
+
+		offset++
+		if offset <= r.ResumeFrom {
+			continue
+		}
+
+		if err := resolveAssetReferences(map[string]any(doc), resolve); err != nil {
+			return &response, err
+		}
+
+		mutation, err := importMutation(operation, doc)
+		if err != nil {
+			return &response, err
+		}
+		batch = append(batch, mutation)
+		ids = append(ids, doc.Id())
+
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return &response, err
+			}
+		}
+	}
+
+	return &response, flush()
+}
+
+// importMutation returns the mutation that writes the document with the
+// specified import operation.
+func importMutation(operation string, doc Document) (Mutation, error) {
+	switch operation {
+	case ImportOperationCreate:
+		return Mutation{Create: doc}, nil
+	case ImportOperationCreateOrReplace:
+		return Mutation{CreateOrReplace: doc}, nil
+	case ImportOperationCreateIfNotExists:
+		return Mutation{CreateIfNotExists: doc}, nil
+	}
+
+	return Mutation{}, fmt.Errorf("invalid import operation '%s'", operation)
+}
+
+// uploadAsset uploads the file referenced by a `_sanityAsset` attribute and
+// returns the identifier of the asset document.
+func (s *ImportService) uploadAsset(ctx context.Context, projectId, dataset string, assets fs.FS, ref string) (string, error) {
+	kind, location, ok := strings.Cut(ref, "@")
+	if !ok || (kind != assetTypeImage && kind != assetTypeFile) {
+		return "", errors.New("invalid asset reference")
+	}
+
+	var body io.ReadCloser
+	switch {
+	case strings.HasPrefix(location, "file://"):
+		if assets == nil {
+			return "", errors.New("no asset file system to read from")
+		}
+
+		f, err := assets.Open(path.Clean(strings.TrimPrefix(location, "file://")))
+		if err != nil {
+			return "", err
+		}
+		body = f
+	case strings.HasPrefix(location, "http://"), strings.HasPrefix(location, "https://"):
+		// Remote assets are downloaded without the credentials of the client,
+		// as they are not necessarily hosted by Sanity.
+		stream, err := openStream(ctx, s.client.publicClient, http.MethodGet, location, nil)
+		if err != nil {
+			return "", err
+		}
+		body = stream
+	default:
+		return "", errors.New("unsupported asset location")
+	}
+	defer body.Close()
+
+	filename := path.Base(location)
+	upload := &UploadAssetRequest{
+		Body:        body,
+		ContentType: mime.TypeByExtension(path.Ext(filename)),
+		Filename:    filename,
+	}
+
+	if _, ok := body.(io.ReadSeeker); !ok {
+		if kind == assetTypeImage {
+			asset, err := s.client.Assets.UploadImage(ctx, projectId, dataset, upload)
+			return asset.Id, err
+		}
+
+		asset, err := s.client.Assets.UploadFile(ctx, projectId, dataset, upload)
+		return asset.Id, err
+	}
+
+	if kind == assetTypeImage {
+		asset, _, err := s.client.Assets.UploadImageIfMissing(ctx, projectId, dataset, upload)
+		return asset.Id, err
+	}
+
+	asset, _, err := s.client.Assets.UploadFileIfMissing(ctx, projectId, dataset, upload)
+	return asset.Id, err
+}
+
+// resolveAssetReferences replaces the `_sanityAsset` attributes in the value
+// with references to the asset identifiers returned by `resolve`. It reverses
+// rewriteAssetReferences.
+func resolveAssetReferences(v any, resolve func(string) (string, error)) error {
+	switch v := v.(type) {
+	case map[string]any:
+		if ref, ok := v["_sanityAsset"].(string); ok {
+			id, err := resolve(ref)
+			if err != nil {
+				return err
+			}
+			delete(v, "_sanityAsset")
+			v["asset"] = map[string]any{"_type": "reference", "_ref": id}
+		}
+		for _, value := range v {
+			if err := resolveAssetReferences(value, resolve); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, value := range v {
+			if err := resolveAssetReferences(value, resolve); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
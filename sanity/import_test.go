@@ -0,0 +1,204 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const testImport = `{"_id":"a","_type":"movie","poster":{"_type":"image","_sanityAsset":"image@file://./images/abc-10x20.png"}}
+{"_id":"b","_type":"movie","poster":{"_type":"image","_sanityAsset":"image@file://./images/abc-10x20.png"}}
+{"_id":"c","_type":"person"}
+`
+
+func TestImportService_Import(t *testing.T) {
+	uploads := 0
+	var mutations []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/data/query/production":
+			w.Write([]byte(`{"result":null}`))
+		case "/assets/images/production":
+			uploads++
+			if r.URL.Query().Get("filename") != "abc-10x20.png" {
+				t.Errorf("Expected filename 'abc-10x20.png', got %s", r.URL.RawQuery)
+			}
+			if r.Header.Get("Content-Type") != "image/png" {
+				t.Errorf("Expected Content-Type 'image/png', got '%s'", r.Header.Get("Content-Type"))
+			}
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != "png bytes" {
+				t.Errorf("Unexpected body '%s'", body)
+			}
+			w.Write([]byte(`{"document":{"_id":"image-new-10x20-png","_type":"sanity.imageAsset"}}`))
+		case "/data/mutate/production":
+			var body struct {
+				Mutations []map[string]any `json:"mutations"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			mutations = append(mutations, body.Mutations...)
+			w.Write([]byte(`{"transactionId":"tx"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	resp, err := client.Import.Import(context.Background(), "test-project", "production", &ImportRequest{
+		Documents: strings.NewReader(testImport),
+		Assets:    fstest.MapFS{"images/abc-10x20.png": {Data: []byte("png bytes")}},
+		Operation: ImportOperationCreateOrReplace,
+		BatchSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Documents != 3 || resp.Assets != 1 {
+		t.Errorf("Expected 3 documents and 1 asset, got %+v", resp)
+	}
+	if uploads != 1 {
+		t.Errorf("Expected 1 upload, got %d", uploads)
+	}
+	if len(mutations) != 3 {
+		t.Fatalf("Expected 3 mutations, got %d", len(mutations))
+	}
+
+	doc := mutations[1]["createOrReplace"].(map[string]any)
+	poster := doc["poster"].(map[string]any)
+	asset, _ := poster["asset"].(map[string]any)
+	if asset["_ref"] != "image-new-10x20-png" || poster["_sanityAsset"] != nil {
+		t.Errorf("Expected the asset reference to be resolved, got %v", poster)
+	}
+}
+
+func TestImportService_Import_Resume(t *testing.T) {
+	var mutated []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Mutations []map[string]map[string]any `json:"mutations"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		for _, mutation := range body.Mutations {
+			mutated = append(mutated, mutation["create"]["_id"].(string))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var imported []string
+	var checkpoints []int
+	_, err := client.Import.Import(context.Background(), "test-project", "production", &ImportRequest{
+		Documents:  strings.NewReader(`{"_id":"a"}` + "\n" + `{"_id":"b"}` + "\n" + `{"_id":"c"}` + "\n" + `{"_id":"d"}` + "\n"),
+		BatchSize:  2,
+		ResumeFrom: 1,
+		OnDocument: func(id string) {
+			imported = append(imported, id)
+		},
+		OnCheckpoint: func(offset int) error {
+			checkpoints = append(checkpoints, offset)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(mutated, ",") != "b,c,d" {
+		t.Errorf("Expected documents b, c, and d to be written, got %v", mutated)
+	}
+	if strings.Join(imported, ",") != "b,c,d" {
+		t.Errorf("Expected progress for b, c, and d, got %v", imported)
+	}
+	if len(checkpoints) != 2 || checkpoints[0] != 3 || checkpoints[1] != 4 {
+		t.Errorf("Expected checkpoints [3 4], got %v", checkpoints)
+	}
+}
+
+func TestImportService_Import_InvalidOperation(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.Import.Import(context.Background(), "test-project", "production", &ImportRequest{
+		Documents: strings.NewReader(`{"_id":"a","_type":"movie"}`),
+		Operation: "upsert",
+	})
+	if err == nil {
+		t.Error("Expected an error for an invalid operation")
+	}
+}
+
+// authTransport adds an API token to requests.
+type authTransport struct{}
+
+func (authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer secret")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestImportService_Import_RemoteAsset(t *testing.T) {
+	assets := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("Expected remote assets to be downloaded without credentials")
+		}
+		if !strings.HasPrefix(r.Header.Get("User-Agent"), "go-sanity/") {
+			t.Errorf("Expected the User-Agent header, got '%s'", r.Header.Get("User-Agent"))
+		}
+		if r.URL.Path == "/slow.png" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("png bytes"))
+	}))
+	defer assets.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/data/query/production":
+			w.Write([]byte(`{"result":null}`))
+		case "/assets/images/production":
+			w.Write([]byte(`{"document":{"_id":"image-new-10x20-png","_type":"sanity.imageAsset"}}`))
+		default:
+			w.Write([]byte(`{"transactionId":"tx"}`))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(&http.Client{Transport: authTransport{}}, WithTimeout(100*time.Millisecond))
+	client.testProjectBaseURL = ts.URL
+
+	for _, tt := range []struct {
+		file    string
+		success bool
+	}{
+		{"abc-10x20.png", true},
+		{"slow.png", false},
+	} {
+		_, err := client.Import.Import(context.Background(), "test-project", "production", &ImportRequest{
+			Documents: strings.NewReader(`{"_id":"a","_type":"movie","poster":{"_type":"image","_sanityAsset":"image@` + assets.URL + `/` + tt.file + `"}}` + "\n"),
+		})
+		if tt.success && err != nil {
+			t.Errorf("%s: Expected no error, got %v", tt.file, err)
+		}
+		if !tt.success && err == nil {
+			t.Errorf("%s: Expected the download to time out", tt.file)
+		}
+	}
+}
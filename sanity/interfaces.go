@@ -0,0 +1,243 @@
+package sanity
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ProjectsAPI is the interface implemented by ProjectsService.
+type ProjectsAPI interface {
+	List(ctx context.Context) ([]Project, error)
+	Iterate() *Iterator[Project]
+	Create(ctx context.Context, r *CreateProjectRequest) (*Project, error)
+	Get(ctx context.Context, projectId string) (*Project, error)
+	Update(ctx context.Context, projectId string, r *UpdateProjectRequest) (*Project, error)
+	Transfer(ctx context.Context, projectId string, r *TransferProjectRequest) (*Project, error)
+	DeleteExternalStudioHost(ctx context.Context, projectId string) (*Project, error)
+	Delete(ctx context.Context, projectId string) (bool, error)
+	ListCORSEntries(ctx context.Context, projectId string) ([]CORSEntry, error)
+	CreateCORSEntry(ctx context.Context, projectId string, r *CreateCORSEntryRequest) (*CORSEntry, error)
+	DeleteCORSEntry(ctx context.Context, projectId string, entryId int64) (bool, error)
+	ListDatasets(ctx context.Context, projectId string) ([]Dataset, error)
+	GetDataset(ctx context.Context, projectId string, datasetName string) (*Dataset, error)
+	CreateDataset(ctx context.Context, projectId string, r *CreateDatasetRequest) (*Dataset, error)
+	EditDataset(ctx context.Context, projectId string, datasetName string, r *EditDatasetRequest) (*Dataset, error)
+	CopyDataset(ctx context.Context, projectId string, r *CopyDatasetRequest) (*CopyDatasetResponse, error)
+	DeleteDataset(ctx context.Context, projectId string, datasetName string) (bool, error)
+	GetDatasetStats(ctx context.Context, projectId string, datasetName string) (*DatasetStats, error)
+	ListJobsHistory(ctx context.Context, projectId string, r *ListJobsHistoryRequest) ([]Job, error)
+	GetDatasetCopyJob(ctx context.Context, projectId string, jobId string) (*Job, error)
+	WaitForCopy(ctx context.Context, projectId string, jobId string, r *WaitForCopyRequest) (*Job, error)
+	ListActiveFeatures(ctx context.Context, projectId string) ([]string, error)
+	CheckFeatureActive(ctx context.Context, projectId string, featureName string) (bool, error)
+	ListPermissions(ctx context.Context, projectId string) ([]string, error)
+	GetUser(ctx context.Context, projectId string, userId string) (*User, error)
+	ListProjectRoles(ctx context.Context, projectId string) ([]ProjectRole, error)
+	ListMembers(ctx context.Context, projectId string) ([]ProjectMember, error)
+	IterateMembers(projectId string) *Iterator[ProjectMember]
+	GetMember(ctx context.Context, projectId, userId string) (*ProjectMember, error)
+	RemoveMember(ctx context.Context, projectId, userId string) (*RemoveMemberResponse, error)
+	ListRobots(ctx context.Context, projectId string) ([]ProjectRobot, error)
+	IterateRobots(projectId string) *Iterator[ProjectRobot]
+	DeleteRobot(ctx context.Context, projectId, robotId string) (bool, error)
+	ListPermissionResources(ctx context.Context, projectId string) ([]PermissionResource, error)
+	GetRole(ctx context.Context, projectId, roleName string) (*AccessRole, error)
+	CreateRole(ctx context.Context, projectId string, role *AccessRole) (*AccessRole, error)
+	CloneRole(ctx context.Context, projectId, sourceRoleName string, r *CloneRoleRequest) (*AccessRole, error)
+	GrantPermission(ctx context.Context, projectId, roleName string, permission RolePermission) (*AccessRole, error)
+	RevokePermission(ctx context.Context, projectId, roleName string, permission RolePermission) (*AccessRole, error)
+	ListProjectTokens(ctx context.Context, projectId string) ([]ProjectToken, error)
+	GetProjectToken(ctx context.Context, projectId string, tokenId string) (*ProjectToken, error)
+	CreateProjectToken(ctx context.Context, projectId string, r *CreateProjectTokenRequest) (*CreateProjectTokenResponse, error)
+	DeleteProjectToken(ctx context.Context, projectId string, tokenId string) (bool, error)
+	ListsDatasetTags(ctx context.Context, projectId, datasetName string) ([]DatasetTag, error)
+	CreateDatasetTag(ctx context.Context, projectId string, r *CreateDatasetTagRequest) (*DatasetTag, error)
+	EditDatasetTag(ctx context.Context, projectId, tagIdentifier string, r *EditDatasetTagRequest) (*DatasetTag, error)
+	AssignDatasetTag(ctx context.Context, projectId, datasetName, tagIdentifier string) error
+	UnassignDatasetTag(ctx context.Context, projectId, datasetName, tagIdentifier string) (bool, error)
+	DeleteDatasetTag(ctx context.Context, projectId, tagIdentifier string) (bool, error)
+}
+
+// WebhooksAPI is the interface implemented by WebhooksService.
+type WebhooksAPI interface {
+	List(ctx context.Context, projectId string) ([]Webhook, error)
+	ListFiltered(ctx context.Context, projectId string, r *ListWebhooksRequest) ([]Webhook, error)
+	Create(ctx context.Context, projectId string, r *CreateWebhookRequest) (*Webhook, error)
+	Get(ctx context.Context, projectId, webhookId string) (*Webhook, error)
+	Update(ctx context.Context, projectId, webhookId string, r *UpdateWebhookRequest) (*Webhook, error)
+	Disable(ctx context.Context, projectId, webhookId string) (*Webhook, error)
+	Enable(ctx context.Context, projectId, webhookId string) (*Webhook, error)
+	RotateSecret(ctx context.Context, projectId, webhookId string) (*RotateWebhookSecretResponse, error)
+	Delete(ctx context.Context, projectId, webhookId string) (bool, error)
+	ListAttempts(ctx context.Context, projectId, webhookId string) ([]WebhookAttempt, error)
+	IterateAttempts(projectId, webhookId string) *Iterator[WebhookAttempt]
+	RetryMessage(ctx context.Context, projectId, webhookId, messageId string) error
+	ListLegacy(ctx context.Context, projectId string) ([]LegacyWebhook, error)
+	CreateLegacy(ctx context.Context, projectId string, r *CreateLegacyWebhookRequest) (*LegacyWebhook, error)
+	DeleteLegacy(ctx context.Context, projectId, webhookId string) (bool, error)
+	Health(ctx context.Context, projectId, webhookId string) (*WebhookHealth, error)
+}
+
+// DocumentsAPI is the interface implemented by DocumentsService.
+type DocumentsAPI interface {
+	Get(ctx context.Context, projectId, dataset, docId string) (Document, error)
+	GetMany(ctx context.Context, projectId, dataset string, docIds []string) ([]Document, []string, error)
+	Query(ctx context.Context, projectId, dataset string, r *QueryRequest, result any) error
+	QueryStream(ctx context.Context, projectId, dataset string, r *QueryRequest, fn func(Document) error) error
+	PaginateQuery(projectId, dataset string, r *PaginateQueryRequest) *QueryPaginator
+	Mutate(ctx context.Context, projectId, dataset string, r *MutateRequest) (*MutateResponse, error)
+	MutateBulk(ctx context.Context, projectId, dataset string, r *BulkMutateRequest) (*BulkMutateResponse, error)
+}
+
+// ActionsAPI is the interface implemented by ActionsService.
+type ActionsAPI interface {
+	Apply(ctx context.Context, projectId, dataset string, r *ApplyActionsRequest) (*ApplyActionsResponse, error)
+}
+
+// AssetsAPI is the interface implemented by AssetsService.
+type AssetsAPI interface {
+	ListImages(ctx context.Context, projectId, dataset string, r *ListAssetsRequest) ([]ImageAsset, error)
+	ListFiles(ctx context.Context, projectId, dataset string, r *ListAssetsRequest) ([]FileAsset, error)
+	FindReferences(ctx context.Context, projectId, dataset, assetId string) ([]Document, error)
+	UploadImage(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*ImageAsset, error)
+	UploadFile(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*FileAsset, error)
+	UploadImageIfMissing(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*ImageAsset, bool, error)
+	UploadFileIfMissing(ctx context.Context, projectId, dataset string, r *UploadAssetRequest) (*FileAsset, bool, error)
+	LinkMediaLibraryAsset(ctx context.Context, projectId, dataset string, r *LinkMediaLibraryAssetRequest) (*Asset, error)
+}
+
+// ListenAPI is the interface implemented by ListenService.
+type ListenAPI interface {
+	Subscribe(ctx context.Context, projectId, dataset string, r *ListenRequest, fn func(ListenEvent) error) error
+	Events(ctx context.Context, projectId, dataset string, r *ListenRequest) (<-chan ListenEvent, <-chan error)
+	Serve(ctx context.Context, projectId, dataset string, mux *ListenMux, r *ListenRequest) error
+}
+
+// ExportAPI is the interface implemented by ExportService.
+type ExportAPI interface {
+	Stream(ctx context.Context, projectId, dataset string, r *ExportRequest) (io.ReadCloser, error)
+	Documents(ctx context.Context, projectId, dataset string, r *ExportRequest, fn func(Document) error) error
+	Archive(ctx context.Context, projectId, dataset string, r *ExportRequest, w io.Writer) error
+}
+
+// ImportAPI is the interface implemented by ImportService.
+type ImportAPI interface {
+	Import(ctx context.Context, projectId, dataset string, r *ImportRequest) (*ImportResponse, error)
+}
+
+// HistoryAPI is the interface implemented by HistoryService.
+type HistoryAPI interface {
+	GetRevision(ctx context.Context, projectId, dataset, docId string, r *GetRevisionRequest) (Document, error)
+	Restore(ctx context.Context, projectId, dataset, docId string, r *GetRevisionRequest) (string, error)
+	ListTransactions(ctx context.Context, projectId, dataset string, r *ListTransactionsRequest, fn func(*Transaction) error) error
+}
+
+// GraphQLAPI is the interface implemented by GraphQLService.
+type GraphQLAPI interface {
+	ListDeployments(ctx context.Context, projectId string) ([]GraphQLDeployment, error)
+	Deploy(ctx context.Context, projectId, dataset, tag string, r *DeployGraphQLRequest) (*DeployGraphQLResponse, error)
+	DeleteDeployment(ctx context.Context, projectId, dataset, tag string) (bool, error)
+	Query(ctx context.Context, projectId, dataset, tag string, r *GraphQLRequest, result any) error
+}
+
+// UsersAPI is the interface implemented by UsersService.
+type UsersAPI interface {
+	GetCurrent(ctx context.Context) (*CurrentUser, error)
+}
+
+// OrganizationsAPI is the interface implemented by OrganizationsService.
+type OrganizationsAPI interface {
+	List(ctx context.Context) ([]Organization, error)
+	Get(ctx context.Context, organizationId string) (*Organization, error)
+}
+
+// SchedulesAPI is the interface implemented by SchedulesService.
+type SchedulesAPI interface {
+	Create(ctx context.Context, projectId, dataset string, r *CreateScheduleRequest) (*Schedule, error)
+	List(ctx context.Context, projectId, dataset string, r *ListSchedulesRequest) ([]Schedule, error)
+	Iterate(projectId, dataset string, r *ListSchedulesRequest) *Iterator[Schedule]
+	Update(ctx context.Context, projectId, dataset, scheduleId string, r *UpdateScheduleRequest) error
+	Delete(ctx context.Context, projectId, dataset, scheduleId string) error
+	Execute(ctx context.Context, projectId, dataset, scheduleId string) error
+	Cancel(ctx context.Context, projectId, dataset, scheduleId string) error
+	Upcoming(ctx context.Context, projectId, dataset, documentId string) ([]Schedule, error)
+	Preflight(ctx context.Context, projectId, dataset string, r *CreateScheduleRequest) ([]ScheduleWarning, error)
+}
+
+// ReleasesAPI is the interface implemented by ReleasesService.
+type ReleasesAPI interface {
+	Create(ctx context.Context, projectId, dataset string, r *CreateReleaseRequest) (*CreateReleaseResponse, error)
+	List(ctx context.Context, projectId, dataset string, r *ListReleasesRequest) ([]Release, error)
+	Documents(ctx context.Context, projectId, dataset, releaseId string) ([]Document, error)
+	Publish(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error)
+	Schedule(ctx context.Context, projectId, dataset, releaseId string, publishAt time.Time) (*ApplyActionsResponse, error)
+	Unschedule(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error)
+	Archive(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error)
+	Unarchive(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error)
+	Delete(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error)
+	CreateVersion(ctx context.Context, projectId, dataset, releaseId string, doc Document) (*ApplyActionsResponse, error)
+	DiscardVersion(ctx context.Context, projectId, dataset, releaseId, docId string) (*ApplyActionsResponse, error)
+}
+
+// CommentsAPI is the interface implemented by CommentsService.
+type CommentsAPI interface {
+	Get(ctx context.Context, projectId, dataset, commentId string) (*Comment, error)
+	Threads(ctx context.Context, projectId, dataset, documentId string) ([]CommentThread, error)
+	AddReaction(ctx context.Context, projectId, dataset, commentId, userId, shortName string) error
+	RemoveReaction(ctx context.Context, projectId, dataset, commentId, userId, shortName string) error
+	Resolve(ctx context.Context, projectId, dataset, threadId string) error
+	Reopen(ctx context.Context, projectId, dataset, threadId string) error
+}
+
+// TasksAPI is the interface implemented by TasksService.
+type TasksAPI interface {
+	Get(ctx context.Context, projectId, dataset, taskId string) (*Task, error)
+	Assign(ctx context.Context, projectId, dataset, taskId, userId string) error
+	Close(ctx context.Context, projectId, dataset, taskId string) error
+	Reopen(ctx context.Context, projectId, dataset, taskId string) error
+	SetStatus(ctx context.Context, projectId, dataset, taskId, status string) error
+}
+
+// EmbeddingsAPI is the interface implemented by EmbeddingsService.
+type EmbeddingsAPI interface {
+	Query(ctx context.Context, projectId, dataset, indexName string, r *EmbeddingsQueryRequest) ([]EmbeddingsResult, error)
+	GetIndex(ctx context.Context, projectId, dataset, indexName string) (*EmbeddingsIndex, error)
+	WaitForIndexReady(ctx context.Context, projectId, dataset, indexName string, r *WaitForIndexReadyRequest) (*EmbeddingsIndex, error)
+}
+
+// AgentActionsAPI is the interface implemented by AgentActionsService.
+type AgentActionsAPI interface {
+	Generate(ctx context.Context, projectId, dataset string, r *GenerateRequest) (Document, error)
+	Prompt(ctx context.Context, projectId, dataset string, r *PromptRequest, result any) error
+}
+
+// SchemasAPI is the interface implemented by SchemasService.
+type SchemasAPI interface {
+	Deploy(ctx context.Context, projectId, dataset string, r *DeploySchemaRequest) (string, error)
+	Get(ctx context.Context, projectId, dataset, workspace, tag string) (*WorkspaceSchema, error)
+	List(ctx context.Context, projectId, dataset string) ([]WorkspaceSchema, error)
+}
+
+// The services implement their interfaces.
+var (
+	_ ProjectsAPI      = (*ProjectsService)(nil)
+	_ WebhooksAPI      = (*WebhooksService)(nil)
+	_ DocumentsAPI     = (*DocumentsService)(nil)
+	_ ActionsAPI       = (*ActionsService)(nil)
+	_ AssetsAPI        = (*AssetsService)(nil)
+	_ ListenAPI        = (*ListenService)(nil)
+	_ ExportAPI        = (*ExportService)(nil)
+	_ ImportAPI        = (*ImportService)(nil)
+	_ HistoryAPI       = (*HistoryService)(nil)
+	_ GraphQLAPI       = (*GraphQLService)(nil)
+	_ UsersAPI         = (*UsersService)(nil)
+	_ OrganizationsAPI = (*OrganizationsService)(nil)
+	_ SchedulesAPI     = (*SchedulesService)(nil)
+	_ ReleasesAPI      = (*ReleasesService)(nil)
+	_ CommentsAPI      = (*CommentsService)(nil)
+	_ TasksAPI         = (*TasksService)(nil)
+	_ EmbeddingsAPI    = (*EmbeddingsService)(nil)
+	_ AgentActionsAPI  = (*AgentActionsService)(nil)
+	_ SchemasAPI       = (*SchemasService)(nil)
+)
@@ -0,0 +1,550 @@
+package sanity
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ListenService is a client for the Sanity Listen API.
+//
+// The Listen API streams mutation events for the documents that match a GROQ
+// filter as Server-Sent Events.
+//
+// Refer to https://www.sanity.io/docs/listening for more information.
+type ListenService service
+
+const (
+	ListenEventWelcome      = "welcome"
+	ListenEventMutation     = "mutation"
+	ListenEventReconnect    = "reconnect"
+	ListenEventDisconnect   = "disconnect"
+	ListenEventChannelError = "channelError"
+)
+
+// ListenRequest describes the documents to listen to.
+type ListenRequest struct {
+	// Query is a GROQ filter that selects the documents to listen to, e.g.,
+	// `*[_type == "movie"]`. Projections and ordering are not supported.
+	Query string
+
+	// Params are the values for the parameters referenced in the query.
+	Params map[string]any
+
+	// IncludeResult indicates whether mutation events include the document as
+	// it is after the mutation.
+	IncludeResult bool
+
+	// IncludePreviousRevision indicates whether mutation events include the
+	// document as it was before the mutation.
+	IncludePreviousRevision bool
+
+	// Visibility controls when mutation events are emitted. Valid values are
+	// represented as the `Visibility*` constants in this package.
+	Visibility string
+
+	// DisableReconnect prevents reconnecting when the connection is lost.
+	DisableReconnect bool
+
+	// MaxReconnectAttempts is the maximum number of consecutive attempts to
+	// reconnect before giving up. Defaults to 5.
+	MaxReconnectAttempts int
+
+	// ReconnectDelay is the delay before the first attempt to reconnect. The
+	// delay doubles with each consecutive attempt, up to 30 seconds. Defaults
+	// to 1 second.
+	ReconnectDelay time.Duration
+
+	// HeartbeatTimeout is the maximum time to wait for data, including
+	// keep-alive messages, before the connection is considered stale. A stale
+	// connection is torn down and reconnected. The timeout should be longer
+	// than the keep-alive interval of the server. Defaults to no timeout.
+	HeartbeatTimeout time.Duration
+
+	// Monitor records the health of the connection, if set.
+	Monitor *ListenMonitor
+}
+
+// A ListenMonitor records the health of a listener connection for use in
+// health checks.
+type ListenMonitor struct {
+	mu            sync.Mutex
+	lastHeartbeat time.Time
+}
+
+// LastHeartbeat returns the last time any data, including keep-alive messages,
+// was received from the server. The zero time is returned if no data has been
+// received.
+func (m *ListenMonitor) LastHeartbeat() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.lastHeartbeat
+}
+
+func (m *ListenMonitor) beat(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastHeartbeat = t
+}
+
+// values returns the request encoded as query string values.
+func (r *ListenRequest) values() (url.Values, error) {
+	values := url.Values{}
+	values.Set("query", r.Query)
+	for name, value := range r.Params {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		values.Set("$"+name, string(b))
+	}
+	if r.IncludeResult {
+		values.Set("includeResult", "true")
+	}
+	if r.IncludePreviousRevision {
+		values.Set("includePreviousRevision", "true")
+	}
+	if r.Visibility != "" {
+		values.Set("visibility", r.Visibility)
+	}
+
+	return values, nil
+}
+
+// A ListenEvent is an event received from the Listen API. The event is one of
+// the following types: *WelcomeEvent, *MutationEvent, *ReconnectEvent,
+// *DisconnectEvent, or *ChannelErrorEvent.
+//
+// Use a type switch to handle the events:
+//
+//	switch e := event.(type) {
+//	case *sanity.MutationEvent:
+//		// ...
+//	case *sanity.DisconnectEvent:
+//		// ...
+//	}
+type ListenEvent interface {
+	// EventType returns the type of the event. Valid values are represented as
+	// the `ListenEvent*` constants in this package.
+	EventType() string
+}
+
+// A WelcomeEvent is received when the listener is connected.
+type WelcomeEvent struct {
+	// ListenerName is the unique name of the listener connection.
+	ListenerName string `json:"listenerName"`
+}
+
+func (e *WelcomeEvent) EventType() string { return ListenEventWelcome }
+
+const (
+	// TransitionUpdate means the document matched the query both before and
+	// after the mutation.
+	TransitionUpdate = "update"
+
+	// TransitionAppear means the document did not match the query before the
+	// mutation but does after it, e.g., because it was created.
+	TransitionAppear = "appear"
+
+	// TransitionDisappear means the document matched the query before the
+	// mutation but does not after it, e.g., because it was deleted.
+	TransitionDisappear = "disappear"
+)
+
+// A MutationEvent is received when a document matching the query is mutated.
+type MutationEvent struct {
+	// EventId is the identifier of the event.
+	EventId string `json:"eventId"`
+
+	// DocumentId is the identifier of the mutated document.
+	DocumentId string `json:"documentId"`
+
+	// TransactionId is the identifier of the transaction the mutation was part
+	// of.
+	TransactionId string `json:"transactionId"`
+
+	// Transition describes how the mutation affected whether the document
+	// matches the query. Valid values are represented as the `Transition*`
+	// constants in this package.
+	Transition string `json:"transition"`
+
+	// Identity is the identifier of the user that performed the mutation.
+	Identity string `json:"identity"`
+
+	// Mutations are the mutations applied to the document in the transaction.
+	Mutations []json.RawMessage `json:"mutations"`
+
+	// Result is the document after the mutation. It is only present when
+	// requested with `IncludeResult`, and is nil if the document was deleted.
+	Result Document `json:"result,omitempty"`
+
+	// Previous is the document before the mutation. It is only present when
+	// requested with `IncludePreviousRevision`.
+	Previous Document `json:"previous,omitempty"`
+
+	// PreviousRev is the revision of the document before the mutation.
+	PreviousRev string `json:"previousRev,omitempty"`
+
+	// ResultRev is the revision of the document after the mutation.
+	ResultRev string `json:"resultRev,omitempty"`
+
+	// Timestamp is the time the mutation was applied.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Visibility describes whether the mutation is visible to queries at the
+	// time of the event.
+	Visibility string `json:"visibility,omitempty"`
+
+	// TransactionTotalEvents is the number of events emitted for the
+	// transaction.
+	TransactionTotalEvents int `json:"transactionTotalEvents"`
+
+	// TransactionCurrentEvent is the position of the event among the events
+	// emitted for the transaction, starting from 1.
+	TransactionCurrentEvent int `json:"transactionCurrentEvent"`
+}
+
+func (e *MutationEvent) EventType() string { return ListenEventMutation }
+
+// A ReconnectEvent is received when the server asks the client to reconnect.
+// Subscribe reconnects automatically unless reconnection is disabled.
+type ReconnectEvent struct{}
+
+func (e *ReconnectEvent) EventType() string { return ListenEventReconnect }
+
+// A DisconnectEvent is received when the server closes the stream and the
+// client must not reconnect.
+type DisconnectEvent struct {
+	// Reason describes why the stream was closed.
+	Reason string `json:"reason"`
+}
+
+func (e *DisconnectEvent) EventType() string { return ListenEventDisconnect }
+
+// A ChannelErrorEvent is received when the server fails to stream events, for
+// example because the query is invalid.
+type ChannelErrorEvent struct {
+	// Message describes the error.
+	Message string `json:"message"`
+}
+
+func (e *ChannelErrorEvent) EventType() string { return ListenEventChannelError }
+
+// decodeListenEvent decodes the payload of a server-sent event into a typed
+// event. A nil event is returned for event types that are not recognized.
+func decodeListenEvent(sse *serverSentEvent) (ListenEvent, error) {
+	var event ListenEvent
+	switch sse.Type {
+	case ListenEventWelcome:
+		event = &WelcomeEvent{}
+	case ListenEventMutation:
+		event = &MutationEvent{}
+	case ListenEventReconnect:
+		return &ReconnectEvent{}, nil
+	case ListenEventDisconnect:
+		event = &DisconnectEvent{}
+	case ListenEventChannelError:
+		event = &ChannelErrorEvent{}
+	default:
+		return nil, nil
+	}
+
+	if len(sse.Data) > 0 {
+		if err := json.Unmarshal(sse.Data, event); err != nil {
+			return nil, fmt.Errorf("decoding %s event: %w", sse.Type, err)
+		}
+	}
+
+	return event, nil
+}
+
+// ErrListenStreamClosed is returned by Subscribe when the server closes the
+// event stream and it is not reconnected.
+var ErrListenStreamClosed = errors.New("listen stream closed")
+
+const maxReconnectDelay = 30 * time.Second
+
+// Subscribe opens an event stream for the documents that match the request
+// and calls `fn` for each event received.
+//
+// If the connection is lost, Subscribe reconnects and resumes the stream from
+// the last event received using the `Last-Event-ID` header, so that no events
+// are missed. Subscribe gives up after the configured number of consecutive
+// failed attempts and returns an error wrapping the last failure.
+//
+// Subscribe blocks until the context is canceled, the stream fails, or `fn`
+// returns an error. The error returned by `fn` is returned to the caller.
+func (s *ListenService) Subscribe(ctx context.Context, projectId, dataset string, r *ListenRequest, fn func(ListenEvent) error) error {
+	values, err := r.values()
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/data/listen/%s?%s", s.client.projectBaseURL(projectId), dataset, values.Encode())
+
+	maxAttempts := r.MaxReconnectAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+	delay := r.ReconnectDelay
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	stream := &listenStream{url: url, heartbeatTimeout: r.HeartbeatTimeout, monitor: r.Monitor}
+	attempts := 0
+	for {
+		err := s.stream(ctx, stream, fn)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !stream.retry || r.DisableReconnect {
+			return err
+		}
+
+		if stream.connected {
+			attempts = 0
+		}
+		if attempts == maxAttempts {
+			return fmt.Errorf("listen: giving up after %d reconnect attempts: %w", attempts, err)
+		}
+
+		wait := delay << attempts
+		if wait > maxReconnectDelay || wait <= 0 {
+			wait = maxReconnectDelay
+		}
+		attempts++
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Events opens an event stream for the documents that match the request and
+// returns a channel of the events received.
+//
+// The returned error channel receives at most one error, describing why the
+// stream ended, before both channels are closed. The channels are closed
+// without an error when the context is canceled.
+//
+//	events, errs := client.Listen.Events(ctx, projectId, dataset, r)
+//	for event := range events {
+//		// ...
+//	}
+//	if err := <-errs; err != nil {
+//		// ...
+//	}
+func (s *ListenService) Events(ctx context.Context, projectId, dataset string, r *ListenRequest) (<-chan ListenEvent, <-chan error) {
+	events := make(chan ListenEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		err := s.Subscribe(ctx, projectId, dataset, r, func(event ListenEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
+
+// listenStream holds the state of an event stream across reconnections.
+type listenStream struct {
+	url string
+
+	heartbeatTimeout time.Duration
+	monitor          *ListenMonitor
+
+	// lastEventId is the identifier of the last event received.
+	lastEventId string
+
+	// connected indicates whether any events were received on the most recent
+	// connection.
+	connected bool
+
+	// retry indicates whether the most recent connection failed in a manner
+	// that warrants reconnecting.
+	retry bool
+}
+
+// stream connects to the event stream and calls `fn` for each event until the
+// connection ends.
+func (s *ListenService) stream(ctx context.Context, stream *listenStream, fn func(ListenEvent) error) error {
+	stream.connected = false
+	stream.retry = false
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The watchdog tears down the connection if no data is received within the
+	// heartbeat timeout.
+	var stale int32
+	var watchdog *time.Timer
+	if stream.heartbeatTimeout > 0 {
+		watchdog = time.AfterFunc(stream.heartbeatTimeout, func() {
+			atomic.StoreInt32(&stale, 1)
+			cancel()
+		})
+		defer watchdog.Stop()
+	}
+	staleErr := func(err error) error {
+		if atomic.LoadInt32(&stale) == 1 {
+			return fmt.Errorf("listen: no heartbeat received within %s", stream.heartbeatTimeout)
+		}
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, stream.url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if stream.lastEventId != "" {
+		req.Header.Set("Last-Event-ID", stream.lastEventId)
+	}
+
+	resp, err := s.client.client.Do(req)
+	if err != nil {
+		stream.retry = true
+		return staleErr(err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		stream.retry = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return err
+	}
+
+	events := newSSEReader(resp.Body)
+	events.onLine = func() {
+		if stream.monitor != nil {
+			stream.monitor.beat(time.Now())
+		}
+		if watchdog != nil {
+			watchdog.Reset(stream.heartbeatTimeout)
+		}
+	}
+	for {
+		sse, err := events.next()
+		if err == io.EOF {
+			stream.retry = true
+			return ErrListenStreamClosed
+		}
+		if err != nil {
+			stream.retry = true
+			return staleErr(err)
+		}
+
+		stream.connected = true
+		if sse.Id != "" {
+			stream.lastEventId = sse.Id
+		}
+
+		event, err := decodeListenEvent(sse)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			continue
+		}
+
+		if err := fn(event); err != nil {
+			return err
+		}
+		switch e := event.(type) {
+		case *ReconnectEvent:
+			stream.retry = true
+			return errors.New("listen stream closed by server for reconnection")
+		case *DisconnectEvent:
+			return fmt.Errorf("listen stream closed by server: %s", e.Reason)
+		case *ChannelErrorEvent:
+			return fmt.Errorf("listen stream failed: %s", e.Message)
+		}
+	}
+}
+
+// serverSentEvent is an event read from a Server-Sent Events stream.
+type serverSentEvent struct {
+	Id   string
+	Type string
+	Data json.RawMessage
+}
+
+// sseReader reads Server-Sent Events from a stream.
+type sseReader struct {
+	reader *bufio.Reader
+
+	// onLine is called for each line read from the stream, if set.
+	onLine func()
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	return &sseReader{reader: bufio.NewReader(r)}
+}
+
+// next reads and returns the next event from the stream. Comments, such as
+// keep-alive messages, are skipped.
+func (r *sseReader) next() (*serverSentEvent, error) {
+	var event serverSentEvent
+	var data []string
+	for {
+		line, err := r.reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return nil, err
+		}
+		if r.onLine != nil {
+			r.onLine()
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if len(data) == 0 && event.Type == "" {
+				continue
+			}
+			if event.Type == "" {
+				event.Type = "message"
+			}
+			event.Data = json.RawMessage(strings.Join(data, "\n"))
+			return &event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			event.Id = value
+		}
+	}
+}
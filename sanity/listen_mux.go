@@ -0,0 +1,151 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// A ListenMux routes the mutation events of a single listener connection to
+// multiple handlers, so that a service watching many kinds of documents does
+// not need a connection per filter.
+//
+// The filters of the registered routes are combined into the query of the
+// shared listener. Since GROQ is not evaluated by the client, each route also
+// has a Go predicate that decides which events are routed to its handler.
+type ListenMux struct {
+	mu     sync.RWMutex
+	routes []listenRoute
+}
+
+type listenRoute struct {
+	filter string
+	// docType is the document type of the routes registered with HandleType,
+	// which is passed to the filter as a parameter.
+	docType string
+	match   func(*MutationEvent) bool
+	handler func(*MutationEvent) error
+}
+
+// NewListenMux returns an empty ListenMux.
+func NewListenMux() *ListenMux {
+	return &ListenMux{}
+}
+
+// Handle registers a handler for the mutation events of documents that match
+// the GROQ constraint `filter`, e.g., `_type == "movie" && year > 2000`.
+//
+// The filter must not reference parameters. The `match` predicate must select
+// the events of the documents that match the filter, and can inspect the
+// `Result` and `Previous` documents of the event.
+func (m *ListenMux) Handle(filter string, match func(*MutationEvent) bool, handler func(*MutationEvent) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.routes = append(m.routes, listenRoute{filter: filter, match: match, handler: handler})
+}
+
+// HandleType registers a handler for the mutation events of documents of the
+// specified type.
+func (m *ListenMux) HandleType(docType string, handler func(*MutationEvent) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.routes = append(m.routes, listenRoute{
+		docType: docType,
+		match: func(e *MutationEvent) bool {
+			return mutationDocumentType(e) == docType
+		},
+		handler: handler,
+	})
+}
+
+// query returns the query that selects the documents of all routes, and the
+// values of its parameters.
+func (m *ListenMux) query() (string, map[string]any, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.routes) == 0 {
+		return "", nil, errors.New("at least one route is required")
+	}
+
+	filters := make([]string, 0, len(m.routes))
+	params := map[string]any{}
+	for i, route := range m.routes {
+		filter := route.filter
+		if route.docType != "" {
+			name := fmt.Sprintf("type%d", i)
+			filter = "_type == $" + name
+			params[name] = route.docType
+		}
+		filters = append(filters, "("+filter+")")
+	}
+
+	return fmt.Sprintf("*[%s]", strings.Join(filters, " || ")), params, nil
+}
+
+// dispatch calls the handlers of all routes that match the event, in the order
+// they were registered.
+func (m *ListenMux) dispatch(e *MutationEvent) error {
+	m.mu.RLock()
+	routes := m.routes
+	m.mu.RUnlock()
+
+	for _, route := range routes {
+		if !route.match(e) {
+			continue
+		}
+		if err := route.handler(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mutationDocumentType returns the type of the document affected by the
+// event, read from the document after or, if deleted, before the mutation.
+func mutationDocumentType(e *MutationEvent) string {
+	if t := e.Result.Type(); t != "" {
+		return t
+	}
+
+	return e.Previous.Type()
+}
+
+// Serve opens a single event stream for the documents selected by the routes
+// of the mux and dispatches the mutation events to the handlers.
+//
+// The request configures the connection, such as reconnection; its Query and
+// Params are replaced by those of the mux, and the documents before and after
+// each mutation are always included so that events can be routed. The request
+// may be nil.
+//
+// Serve blocks in the same manner as Subscribe. The query is built when Serve
+// is called, so routes registered afterwards only receive the events of
+// documents that are selected by the filters of the earlier routes.
+func (s *ListenService) Serve(ctx context.Context, projectId, dataset string, mux *ListenMux, r *ListenRequest) error {
+	query, params, err := mux.query()
+	if err != nil {
+		return err
+	}
+
+	req := ListenRequest{}
+	if r != nil {
+		req = *r
+	}
+	req.Query = query
+	req.Params = params
+	req.IncludeResult = true
+	req.IncludePreviousRevision = true
+
+	return s.Subscribe(ctx, projectId, dataset, &req, func(event ListenEvent) error {
+		if e, ok := event.(*MutationEvent); ok {
+			return mux.dispatch(e)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,55 @@
+package sanity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListenService_Serve(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedQuery := `*[(_type == $type0) || (_type == $type1)]`
+		if r.URL.Query().Get("query") != expectedQuery {
+			t.Errorf("Expected query '%s', got '%s'", expectedQuery, r.URL.Query().Get("query"))
+		}
+		if r.URL.Query().Get("$type0") != `"movie"` || r.URL.Query().Get("$type1") != `"person"` {
+			t.Errorf("Expected the types as parameters, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("includeResult") != "true" || r.URL.Query().Get("includePreviousRevision") != "true" {
+			t.Errorf("Expected documents to be included, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: welcome\ndata: {}\n\n")
+		fmt.Fprint(w, "event: mutation\ndata: {\"documentId\":\"m1\",\"result\":{\"_id\":\"m1\",\"_type\":\"movie\"}}\n\n")
+		fmt.Fprint(w, "event: mutation\ndata: {\"documentId\":\"p1\",\"previous\":{\"_id\":\"p1\",\"_type\":\"person\"},\"transition\":\"disappear\"}\n\n")
+		fmt.Fprint(w, "event: disconnect\ndata: {\"reason\":\"done\"}\n\n")
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var routed []string
+	mux := NewListenMux()
+	mux.HandleType("movie", func(e *MutationEvent) error {
+		routed = append(routed, "movie:"+e.DocumentId)
+		return nil
+	})
+	mux.HandleType("person", func(e *MutationEvent) error {
+		routed = append(routed, "person:"+e.DocumentId)
+		return nil
+	})
+
+	err := client.Listen.Serve(context.Background(), "test-project", "production", mux, nil)
+	if err == nil || !strings.Contains(err.Error(), "done") {
+		t.Fatalf("Expected disconnect error, got %v", err)
+	}
+
+	if strings.Join(routed, ",") != "movie:m1,person:p1" {
+		t.Errorf("Unexpected routing %v", routed)
+	}
+}
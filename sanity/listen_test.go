@@ -0,0 +1,249 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListenService_Subscribe(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/listen/production" {
+			t.Errorf("Expected /data/listen/production path, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("query") != `*[_type == $type]` {
+			t.Errorf("Unexpected query %s", r.URL.Query().Get("query"))
+		}
+		if r.URL.Query().Get("$type") != `"movie"` {
+			t.Errorf("Expected JSON-encoded param, got %s", r.URL.Query().Get("$type"))
+		}
+		if r.URL.Query().Get("includeResult") != "true" {
+			t.Errorf("Expected includeResult=true, got %s", r.URL.RawQuery)
+		}
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected Accept header 'text/event-stream', got '%s'", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: welcome\ndata: {\"listenerName\":\"abc\"}\n\n")
+		fmt.Fprint(w, "id: evt-1\nevent: mutation\ndata: {\"eventId\":\"evt-1\",\"documentId\":\"movie_1\",\"transition\":\"update\"}\n\n")
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var events []ListenEvent
+	err := client.Listen.Subscribe(context.Background(), "test-project", "production", &ListenRequest{
+		Query:            `*[_type == $type]`,
+		Params:           map[string]any{"type": "movie"},
+		IncludeResult:    true,
+		DisableReconnect: true,
+	}, func(event ListenEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if !errors.Is(err, ErrListenStreamClosed) {
+		t.Fatalf("Expected ErrListenStreamClosed, got %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if welcome, ok := events[0].(*WelcomeEvent); !ok || welcome.ListenerName != "abc" {
+		t.Errorf("Expected welcome event, got %+v", events[0])
+	}
+	mutation, ok := events[1].(*MutationEvent)
+	if !ok {
+		t.Fatalf("Expected mutation event, got %+v", events[1])
+	}
+	if mutation.DocumentId != "movie_1" || mutation.Transition != TransitionUpdate {
+		t.Errorf("Unexpected mutation event %+v", mutation)
+	}
+}
+
+func TestSSEReader_MultilineData(t *testing.T) {
+	r := newSSEReader(strings.NewReader("event: mutation\r\ndata: {\"a\":\r\ndata: 1}\r\n\r\n"))
+
+	event, err := r.next()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if event.Type != "mutation" || string(event.Data) != "{\"a\":\n1}" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+}
+
+func TestListenService_Subscribe_Reconnect(t *testing.T) {
+	var lastEventIds []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIds = append(lastEventIds, r.Header.Get("Last-Event-ID"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch len(lastEventIds) {
+		case 1:
+			fmt.Fprint(w, "event: welcome\ndata: {}\n\n")
+			fmt.Fprint(w, "id: evt-1\nevent: mutation\ndata: {\"eventId\":\"evt-1\",\"documentId\":\"a\"}\n\n")
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			fmt.Fprint(w, "id: evt-2\nevent: mutation\ndata: {\"eventId\":\"evt-2\",\"documentId\":\"b\"}\n\n")
+			fmt.Fprint(w, "event: disconnect\ndata: {\"reason\":\"forbidden\"}\n\n")
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var ids []string
+	err := client.Listen.Subscribe(context.Background(), "test-project", "production", &ListenRequest{
+		Query:          `*`,
+		ReconnectDelay: time.Millisecond,
+	}, func(event ListenEvent) error {
+		ids = append(ids, event.EventType())
+		if mutation, ok := event.(*MutationEvent); ok {
+			ids = append(ids, mutation.EventId)
+		}
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Fatalf("Expected disconnect error, got %v", err)
+	}
+
+	if strings.Join(lastEventIds, ",") != ",evt-1,evt-1" {
+		t.Errorf("Expected Last-Event-ID headers [ evt-1 evt-1], got %v", lastEventIds)
+	}
+	if strings.Join(ids, ",") != "welcome,mutation,evt-1,mutation,evt-2,disconnect" {
+		t.Errorf("Unexpected event IDs %v", ids)
+	}
+}
+
+func TestListenService_Subscribe_GivesUp(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	err := client.Listen.Subscribe(context.Background(), "test-project", "production", &ListenRequest{
+		Query:                `*`,
+		MaxReconnectAttempts: 2,
+		ReconnectDelay:       time.Millisecond,
+	}, func(event ListenEvent) error {
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "giving up after 2 reconnect attempts") {
+		t.Fatalf("Expected terminal error, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestListenService_Events(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: welcome\ndata: {}\n\n")
+		fmt.Fprint(w, "event: mutation\ndata: {\"documentId\":\"a\"}\n\n")
+		fmt.Fprint(w, "event: channelError\ndata: {\"message\":\"invalid query\"}\n\n")
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	events, errs := client.Listen.Events(context.Background(), "test-project", "production", &ListenRequest{Query: `*`})
+
+	var types []string
+	for event := range events {
+		types = append(types, event.EventType())
+	}
+	if strings.Join(types, ",") != "welcome,mutation,channelError" {
+		t.Errorf("Unexpected events %v", types)
+	}
+
+	err := <-errs
+	if err == nil || !strings.Contains(err.Error(), "invalid query") {
+		t.Errorf("Expected channel error, got %v", err)
+	}
+}
+
+func TestListenService_Events_ContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: welcome\ndata: {}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := client.Listen.Events(ctx, "test-project", "production", &ListenRequest{Query: `*`})
+
+	if event := <-events; event.EventType() != ListenEventWelcome {
+		t.Errorf("Expected welcome event, got %v", event)
+	}
+	cancel()
+
+	for range events {
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Expected no error after cancellation, got %v", err)
+	}
+}
+
+func TestListenService_Subscribe_HeartbeatTimeout(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if requests == 1 {
+			// Send a welcome event, then go silent until the client hangs up.
+			fmt.Fprint(w, "event: welcome\ndata: {}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: disconnect\ndata: {\"reason\":\"done\"}\n\n")
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	monitor := &ListenMonitor{}
+	err := client.Listen.Subscribe(context.Background(), "test-project", "production", &ListenRequest{
+		Query:            `*`,
+		ReconnectDelay:   time.Millisecond,
+		HeartbeatTimeout: 50 * time.Millisecond,
+		Monitor:          monitor,
+	}, func(event ListenEvent) error {
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "done") {
+		t.Fatalf("Expected disconnect error, got %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected the stale connection to be reconnected, got %d requests", requests)
+	}
+	if monitor.LastHeartbeat().IsZero() {
+		t.Error("Expected the monitor to record a heartbeat")
+	}
+}
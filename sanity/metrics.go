@@ -0,0 +1,190 @@
+package sanity
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A MetricsRecorder records metrics about the calls the client makes to the
+// Sanity API.
+type MetricsRecorder interface {
+	// ObserveRequest records a completed call. The service and operation
+	// identify the call in the same way as the attributes of the spans
+	// started for WithTracerProvider. The status code is zero and `err` is
+	// non-nil if no response was received, and `err` is also non-nil if the
+	// response has an unsuccessful status code.
+	ObserveRequest(service, operation string, statusCode int, duration time.Duration, err error)
+}
+
+// WithMetrics makes the client record metrics about its calls to the Sanity
+// API with the recorder. Use NewMetrics for a recorder that is exposed in the
+// Prometheus text format.
+func WithMetrics(m MetricsRecorder) ClientOption {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the buckets of the
+// latency histogram of NewMetrics.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics is a MetricsRecorder that keeps metrics in memory and serves them in
+// the Prometheus text exposition format. It records the following metrics,
+// labeled by service and operation:
+//
+//   - `sanity_requests_total`: a counter of calls, also labeled by status code
+//   - `sanity_request_errors_total`: a counter of failed calls
+//   - `sanity_request_duration_seconds`: a histogram of call latency
+//
+// Serve the metrics by registering the Metrics as an HTTP handler, e.g., at
+// `/metrics`, or write them along with other metrics with WriteTo.
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[metricsKey]uint64
+	errors    map[metricsKey]uint64
+	latencies map[metricsKey]*histogram
+}
+
+type metricsKey struct {
+	service   string
+	operation string
+	code      int
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewMetrics returns an empty Metrics with the latency buckets, which default
+// to DefaultLatencyBuckets.
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	return &Metrics{
+		buckets:   buckets,
+		requests:  map[metricsKey]uint64{},
+		errors:    map[metricsKey]uint64{},
+		latencies: map[metricsKey]*histogram{},
+	}
+}
+
+func (m *Metrics) ObserveRequest(service, operation string, statusCode int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := metricsKey{service: service, operation: operation}
+	m.requests[metricsKey{service: service, operation: operation, code: statusCode}]++
+	if err != nil {
+		m.errors[key]++
+	}
+
+	h, ok := m.latencies[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[key] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to `w` in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+
+	fmt.Fprintln(cw, "# HELP sanity_requests_total Total number of calls to the Sanity API.")
+	fmt.Fprintln(cw, "# TYPE sanity_requests_total counter")
+	for _, key := range sortedMetricsKeys(m.requests) {
+		fmt.Fprintf(cw, "sanity_requests_total{%s,code=\"%d\"} %d\n", key.labels(), key.code, m.requests[key])
+	}
+
+	fmt.Fprintln(cw, "# HELP sanity_request_errors_total Total number of failed calls to the Sanity API.")
+	fmt.Fprintln(cw, "# TYPE sanity_request_errors_total counter")
+	for _, key := range sortedMetricsKeys(m.errors) {
+		fmt.Fprintf(cw, "sanity_request_errors_total{%s} %d\n", key.labels(), m.errors[key])
+	}
+
+	fmt.Fprintln(cw, "# HELP sanity_request_duration_seconds Latency of calls to the Sanity API.")
+	fmt.Fprintln(cw, "# TYPE sanity_request_duration_seconds histogram")
+	for _, key := range sortedMetricsKeys(m.latencies) {
+		h := m.latencies[key]
+		for i, bound := range m.buckets {
+			fmt.Fprintf(cw, "sanity_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", key.labels(), strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(cw, "sanity_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key.labels(), h.count)
+		fmt.Fprintf(cw, "sanity_request_duration_seconds_sum{%s} %s\n", key.labels(), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(cw, "sanity_request_duration_seconds_count{%s} %d\n", key.labels(), h.count)
+	}
+
+	return cw.n, cw.err
+}
+
+func (k metricsKey) labels() string {
+	return fmt.Sprintf("service=%q,operation=%q", k.service, k.operation)
+}
+
+// sortedMetricsKeys returns the keys of the map in a stable order.
+func sortedMetricsKeys[V any](m map[metricsKey]V) []metricsKey {
+	keys := make([]metricsKey, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].service != keys[j].service {
+			return keys[i].service < keys[j].service
+		}
+		if keys[i].operation != keys[j].operation {
+			return keys[i].operation < keys[j].operation
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	return keys
+}
+
+// countingWriter counts the bytes written to the underlying writer and keeps
+// the first error.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	w.err = err
+
+	return n, err
+}
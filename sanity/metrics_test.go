@@ -0,0 +1,75 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2021-06-07/projects/test-project" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer ts.Close()
+
+	metrics := NewMetrics()
+	client := NewClient(http.DefaultClient, WithMetrics(metrics))
+	client.baseURL = ts.URL
+	client.testProjectBaseURL = ts.URL
+
+	var docs []Document
+	for i := 0; i < 2; i++ {
+		client.Documents.Query(context.Background(), "test-project", "production", &QueryRequest{Query: "*"}, &docs)
+	}
+	client.Projects.Get(context.Background(), "test-project")
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+
+	expected := []string{
+		`sanity_requests_total{service="data",operation="query",code="200"} 2`,
+		`sanity_requests_total{service="projects",operation="GET",code="404"} 1`,
+		`sanity_request_errors_total{service="projects",operation="GET"} 1`,
+		`sanity_request_duration_seconds_count{service="data",operation="query"} 2`,
+		`sanity_request_duration_seconds_bucket{service="data",operation="query",le="+Inf"} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("Expected metrics to contain '%s', got:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, `sanity_request_errors_total{service="data"`) {
+		t.Errorf("Expected no errors for successful calls, got:\n%s", out)
+	}
+}
+
+func TestMetrics_Buckets(t *testing.T) {
+	metrics := NewMetrics(1, 0.1)
+	metrics.ObserveRequest("data", "query", 0, 500*time.Millisecond, errors.New("timeout"))
+
+	var b strings.Builder
+	if _, err := metrics.WriteTo(&b); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, line := range []string{
+		`sanity_request_duration_seconds_bucket{service="data",operation="query",le="0.1"} 0`,
+		`sanity_request_duration_seconds_bucket{service="data",operation="query",le="1"} 1`,
+		`sanity_request_duration_seconds_sum{service="data",operation="query"} 0.5`,
+		`sanity_requests_total{service="data",operation="query",code="0"} 1`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("Expected metrics to contain '%s', got:\n%s", line, b.String())
+		}
+	}
+}
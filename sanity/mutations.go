@@ -0,0 +1,383 @@
+package sanity
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// A Mutation is a single change to apply to the documents in a dataset.
+//
+// Exactly one of the fields should be set. The document values may be either
+// a Document or any value that encodes to a JSON object with an `_id` and a
+// `_type` attribute.
+//
+// Refer to https://www.sanity.io/docs/http-mutations for more information.
+type Mutation struct {
+	// Create creates a new document. The mutation fails if a document with the
+	// same identifier already exists.
+	Create any `json:"create,omitempty"`
+
+	// CreateOrReplace creates a new document, replacing any existing document
+	// with the same identifier.
+	CreateOrReplace any `json:"createOrReplace,omitempty"`
+
+	// CreateIfNotExists creates a new document unless a document with the same
+	// identifier already exists.
+	CreateIfNotExists any `json:"createIfNotExists,omitempty"`
+
+	// Delete removes the documents that match the selection.
+	Delete *DeleteMutation `json:"delete,omitempty"`
+
+	// Patch changes attributes of the documents that match the selection.
+	Patch *Patch `json:"patch,omitempty"`
+}
+
+// A DeleteMutation selects the documents to delete.
+type DeleteMutation struct {
+	// Id is the identifier of the document to delete.
+	Id string `json:"id,omitempty"`
+
+	// Query is a GROQ query that selects the documents to delete. It is used
+	// instead of Id to delete multiple documents.
+	Query string `json:"query,omitempty"`
+
+	// Params are the values for the parameters referenced in the query.
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// A Patch describes changes to the attributes of one or more documents.
+//
+// The operations are applied in the following order: set, setIfMissing,
+// unset, inc, dec, insert, diffMatchPatch.
+type Patch struct {
+	// Id is the identifier of the document to patch.
+	Id string `json:"id,omitempty"`
+
+	// Query is a GROQ query that selects the documents to patch. It is used
+	// instead of Id to patch multiple documents.
+	Query string `json:"query,omitempty"`
+
+	// Params are the values for the parameters referenced in the query.
+	Params map[string]any `json:"params,omitempty"`
+
+	// IfRevisionId makes the patch fail unless the document has the specified
+	// revision. This is used for optimistic locking.
+	IfRevisionId string `json:"ifRevisionID,omitempty"`
+
+	// Set replaces the values at the specified paths.
+	Set map[string]any `json:"set,omitempty"`
+
+	// SetIfMissing sets the values at the specified paths unless they are
+	// already present.
+	SetIfMissing map[string]any `json:"setIfMissing,omitempty"`
+
+	// Unset removes the values at the specified paths.
+	Unset []string `json:"unset,omitempty"`
+
+	// Inc increments the numeric values at the specified paths.
+	Inc map[string]float64 `json:"inc,omitempty"`
+
+	// Dec decrements the numeric values at the specified paths.
+	Dec map[string]float64 `json:"dec,omitempty"`
+
+	// Insert adds items to an array.
+	Insert *PatchInsert `json:"insert,omitempty"`
+
+	// DiffMatchPatch applies diff-match-patch patches to the string values at
+	// the specified paths.
+	DiffMatchPatch map[string]string `json:"diffMatchPatch,omitempty"`
+}
+
+// A PatchInsert adds items to an array relative to an existing position.
+//
+// Exactly one of Before, After, and Replace should be set to a path selecting
+// an array item, e.g., `tags[-1]`.
+type PatchInsert struct {
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after,omitempty"`
+	Replace string `json:"replace,omitempty"`
+	Items   []any  `json:"items"`
+}
+
+const (
+	// VisibilitySync waits until the changes are visible to queries before
+	// returning. This is the default.
+	VisibilitySync = "sync"
+
+	// VisibilityAsync returns once the changes are committed, before they are
+	// visible to queries.
+	VisibilityAsync = "async"
+
+	// VisibilityDeferred returns once the changes are committed and delays
+	// making them visible to queries. This yields the highest throughput for
+	// bulk writes.
+	VisibilityDeferred = "deferred"
+)
+
+// MutateRequest is a transaction of mutations that are applied atomically.
+type MutateRequest struct {
+	// Mutations are the changes to apply, in order.
+	Mutations []Mutation `json:"mutations"`
+
+	// TransactionId is an optional identifier for the transaction. If left
+	// blank, an identifier is generated by Sanity, or by the client if retries
+	// are enabled.
+	TransactionId string `json:"transactionId,omitempty"`
+
+	// Visibility controls when the changes become visible to queries. Valid
+	// values are represented as the `Visibility*` constants in this package.
+	// Defaults to VisibilitySync.
+	Visibility string `json:"-"`
+
+	// ReturnIds indicates whether the identifiers of the affected documents are
+	// included in the results. Defaults to true.
+	ReturnIds *bool `json:"-"`
+
+	// ReturnDocuments indicates whether the full affected documents, as they
+	// are after the transaction, are included in the results. This avoids a
+	// follow-up query for the updated documents.
+	ReturnDocuments bool `json:"-"`
+}
+
+// MutateResponse describes the outcome of a transaction.
+type MutateResponse struct {
+	// TransactionId is the identifier of the transaction. This is also the
+	// revision of the documents that were changed.
+	TransactionId string `json:"transactionId"`
+
+	// Results describe the outcome of each mutation.
+	Results []MutationResult `json:"results"`
+}
+
+// A MutationResult describes the outcome of a single mutation.
+type MutationResult struct {
+	// Id is the identifier of the affected document.
+	Id string `json:"id"`
+
+	// Operation is the operation applied to the document, e.g., `create`,
+	// `update`, or `delete`.
+	Operation string `json:"operation"`
+
+	// Document is the affected document after the transaction. It is only
+	// present when requested with `ReturnDocuments`.
+	Document Document `json:"document,omitempty"`
+}
+
+// DocumentIds returns the identifiers of the documents affected by the
+// transaction.
+func (r *MutateResponse) DocumentIds() []string {
+	ids := make([]string, 0, len(r.Results))
+	for _, result := range r.Results {
+		ids = append(ids, result.Id)
+	}
+
+	return ids
+}
+
+// Documents returns the documents affected by the transaction. The result is
+// empty unless the documents were requested with `ReturnDocuments`.
+func (r *MutateResponse) Documents() []Document {
+	var docs []Document
+	for _, result := range r.Results {
+		if result.Document != nil {
+			docs = append(docs, result.Document)
+		}
+	}
+
+	return docs
+}
+
+// Mutate applies the mutations in the request as a single transaction. Either
+// all the mutations succeed or none of them are applied.
+func (s *DocumentsService) Mutate(ctx context.Context, projectId, dataset string, r *MutateRequest) (*MutateResponse, error) {
+	if len(r.Mutations) == 0 {
+		return nil, errors.New("at least one mutation is required")
+	}
+
+	params := url.Values{}
+	params.Set("returnIds", "true")
+	if r.ReturnIds != nil && !*r.ReturnIds {
+		params.Set("returnIds", "false")
+	}
+	if r.ReturnDocuments {
+		params.Set("returnDocuments", "true")
+	}
+	if r.Visibility != "" {
+		params.Set("visibility", r.Visibility)
+	}
+
+	url := fmt.Sprintf("%s/data/mutate/%s?%s", s.client.projectBaseURL(projectId), dataset, params.Encode())
+
+	req := *r
+	transactionId, err := s.client.transactionId(req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	req.TransactionId = transactionId
+
+	var response MutateResponse
+	err = do(ctx, s.client.client, url, http.MethodPost, &req, &response)
+
+	return &response, err
+}
+
+// transactionId returns the identifier to send for a transaction. If retries
+// are enabled and `id` is blank, a random identifier is generated, so that a
+// transaction that is retried cannot be applied twice.
+func (c *Client) transactionId(id string) (string, error) {
+	if id != "" || c.maxRetries == 0 {
+		return id, nil
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// BulkMutateRequest describes an arbitrary number of mutations to apply in
+// batches.
+type BulkMutateRequest struct {
+	// Mutations are the changes to apply.
+	Mutations []Mutation
+
+	// MaxBatchSize is the maximum number of mutations in a transaction.
+	// Defaults to 100.
+	MaxBatchSize int
+
+	// MaxBatchBytes is the maximum encoded size of the mutations in a
+	// transaction. Defaults to 2 MB.
+	MaxBatchBytes int
+
+	// Concurrency is the maximum number of transactions in flight at once.
+	// Defaults to 1, which applies the transactions in order.
+	Concurrency int
+
+	// Visibility controls when the changes become visible to queries. Valid
+	// values are represented as the `Visibility*` constants in this package.
+	Visibility string
+}
+
+// BulkMutateResponse aggregates the outcome of the transactions applied by
+// MutateBulk.
+type BulkMutateResponse struct {
+	// TransactionIds are the identifiers of the transactions that succeeded,
+	// in the order of their mutations.
+	TransactionIds []string
+
+	// Results describe the outcome of each mutation in the transactions that
+	// succeeded, in order.
+	Results []MutationResult
+}
+
+// MutateBulk applies any number of mutations by transparently chunking them
+// into transactions that respect the size limits of the API.
+//
+// Mutations are only atomic within a transaction. If a transaction fails, no
+// further transactions are started and the error is returned along with the
+// outcome of the transactions that succeeded.
+func (s *DocumentsService) MutateBulk(ctx context.Context, projectId, dataset string, r *BulkMutateRequest) (*BulkMutateResponse, error) {
+	batches, err := chunkMutations(r.Mutations, r.MaxBatchSize, r.MaxBatchBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make([]*MutateResponse, len(batches))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i, batch := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, batch []Mutation) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := s.Mutate(ctx, projectId, dataset, &MutateRequest{Mutations: batch, Visibility: r.Visibility})
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			responses[i] = resp
+		}(i, batch)
+	}
+	wg.Wait()
+
+	result := &BulkMutateResponse{}
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		result.TransactionIds = append(result.TransactionIds, resp.TransactionId)
+		result.Results = append(result.Results, resp.Results...)
+	}
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+
+	return result, firstErr
+}
+
+// chunkMutations splits the mutations into batches of at most `maxSize`
+// mutations and `maxBytes` encoded bytes. A mutation that exceeds `maxBytes` on
+// its own is placed in a batch by itself.
+func chunkMutations(mutations []Mutation, maxSize, maxBytes int) ([][]Mutation, error) {
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+	if maxBytes <= 0 {
+		maxBytes = 2 << 20
+	}
+
+	var batches [][]Mutation
+	var batch []Mutation
+	batchBytes := 0
+	for _, m := range mutations {
+		b, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(batch) > 0 && (len(batch) == maxSize || batchBytes+len(b) > maxBytes) {
+			batches = append(batches, batch)
+			batch = nil
+			batchBytes = 0
+		}
+		batch = append(batch, m)
+		batchBytes += len(b)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
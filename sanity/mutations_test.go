@@ -0,0 +1,208 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDocumentsService_Mutate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/data/mutate/production" {
+			t.Errorf("Expected /data/mutate/production path, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("returnIds") != "true" {
+			t.Errorf("Expected returnIds=true, got %s", r.URL.RawQuery)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if body["transactionId"] != "tx-1" {
+			t.Errorf("Expected transactionId 'tx-1', got '%v'", body["transactionId"])
+		}
+		mutations := body["mutations"].([]any)
+		if len(mutations) != 2 {
+			t.Fatalf("Expected 2 mutations, got %d", len(mutations))
+		}
+		if _, ok := mutations[0].(map[string]any)["create"]; !ok {
+			t.Errorf("Expected first mutation to be a create, got %v", mutations[0])
+		}
+		patch := mutations[1].(map[string]any)["patch"].(map[string]any)
+		if patch["id"] != "b" || patch["ifRevisionID"] != "rev-1" {
+			t.Errorf("Unexpected patch %v", patch)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1","results":[{"id":"a","operation":"create"},{"id":"b","operation":"update"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	resp, err := client.Documents.Mutate(context.Background(), "test-project", "production", &MutateRequest{
+		TransactionId: "tx-1",
+		Mutations: []Mutation{
+			{Create: Document{"_id": "a", "_type": "movie"}},
+			{Patch: &Patch{Id: "b", IfRevisionId: "rev-1", Set: map[string]any{"title": "Aliens"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.TransactionId != "tx-1" {
+		t.Errorf("Expected transactionId 'tx-1', got '%s'", resp.TransactionId)
+	}
+	ids := resp.DocumentIds()
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Errorf("Expected document IDs [a b], got %v", ids)
+	}
+}
+
+func TestMutation_MarshalOmitsUnsetOperations(t *testing.T) {
+	b, err := json.Marshal(Mutation{Delete: &DeleteMutation{Id: "a"}})
+	if err != nil {
+		t.Fatalf("Failed to marshal Mutation: %v", err)
+	}
+
+	if string(b) != `{"delete":{"id":"a"}}` {
+		t.Errorf("Unexpected JSON: %s", b)
+	}
+}
+
+func TestDocumentsService_Mutate_Visibility(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("visibility") != VisibilityDeferred {
+			t.Errorf("Expected visibility=deferred, got %s", r.URL.RawQuery)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["visibility"]; ok {
+			t.Errorf("Expected visibility to be omitted from the body, got %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1","results":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	_, err := client.Documents.Mutate(context.Background(), "test-project", "production", &MutateRequest{
+		Mutations:  []Mutation{{Delete: &DeleteMutation{Id: "a"}}},
+		Visibility: VisibilityDeferred,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestDocumentsService_Mutate_ReturnDocuments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("returnDocuments") != "true" {
+			t.Errorf("Expected returnDocuments=true, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("returnIds") != "false" {
+			t.Errorf("Expected returnIds=false, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1","results":[{"id":"a","operation":"update","document":{"_id":"a","_rev":"tx-1","title":"Aliens"}}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	resp, err := client.Documents.Mutate(context.Background(), "test-project", "production", &MutateRequest{
+		Mutations:       []Mutation{{Patch: &Patch{Id: "a", Set: map[string]any{"title": "Aliens"}}}},
+		ReturnIds:       NewBool(false),
+		ReturnDocuments: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	docs := resp.Documents()
+	if len(docs) != 1 {
+		t.Fatalf("Expected 1 document, got %d", len(docs))
+	}
+	if docs[0].Rev() != "tx-1" || docs[0]["title"] != "Aliens" {
+		t.Errorf("Unexpected document %v", docs[0])
+	}
+}
+
+func TestDocumentsService_MutateBulk(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+
+		var req MutateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var results []string
+		for _, m := range req.Mutations {
+			results = append(results, fmt.Sprintf(`{"id":%q,"operation":"delete"}`, m.Delete.Id))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"transactionId":"tx-%d","results":[%s]}`, n, strings.Join(results, ","))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var mutations []Mutation
+	for i := 0; i < 5; i++ {
+		mutations = append(mutations, Mutation{Delete: &DeleteMutation{Id: fmt.Sprintf("doc-%d", i)}})
+	}
+
+	resp, err := client.Documents.MutateBulk(context.Background(), "test-project", "production", &BulkMutateRequest{
+		Mutations:    mutations,
+		MaxBatchSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected 3 transactions, got %d", calls)
+	}
+	if len(resp.TransactionIds) != 3 {
+		t.Errorf("Expected 3 transaction IDs, got %v", resp.TransactionIds)
+	}
+	if len(resp.Results) != 5 || resp.Results[4].Id != "doc-4" {
+		t.Errorf("Expected 5 results in order, got %v", resp.Results)
+	}
+}
+
+func TestChunkMutations_MaxBatchBytes(t *testing.T) {
+	mutations := []Mutation{
+		{Create: Document{"_id": "a", "body": strings.Repeat("x", 100)}},
+		{Create: Document{"_id": "b", "body": strings.Repeat("x", 100)}},
+		{Create: Document{"_id": "c"}},
+	}
+
+	batches, err := chunkMutations(mutations, 100, 200)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 1 || len(batches[1]) != 2 {
+		t.Errorf("Expected batches of sizes [1 2], got %v", batches)
+	}
+}
@@ -0,0 +1,55 @@
+package sanity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OrganizationsService is a client for the Sanity Organizations API.
+//
+// Refer to https://www.sanity.io/docs/http-reference for more information.
+type OrganizationsService service
+
+// An Organization is a Sanity organization that owns projects.
+type Organization struct {
+	// Id is the unique identifier for the organization.
+	Id string `json:"id"`
+
+	// Name is the display-friendly name of the organization.
+	Name string `json:"name"`
+
+	// Slug is the URL-friendly name of the organization.
+	Slug string `json:"slug,omitempty"`
+
+	// DefaultRoleName is the role assigned to new members of the
+	// organization.
+	DefaultRoleName string `json:"defaultRoleName,omitempty"`
+
+	// CreatedAt is the creation time of the organization.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is the last time the organization was updated.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// List fetches and returns all the organizations the client has access to.
+func (s *OrganizationsService) List(ctx context.Context) ([]Organization, error) {
+	url := fmt.Sprintf("%s/v2021-06-07/organizations", s.client.baseURL)
+
+	var organizations []Organization
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &organizations)
+
+	return organizations, err
+}
+
+// Get fetches and returns the specified organization.
+func (s *OrganizationsService) Get(ctx context.Context, organizationId string) (*Organization, error) {
+	url := fmt.Sprintf("%s/v2021-06-07/organizations/%s", s.client.baseURL, organizationId)
+
+	var organization Organization
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &organization)
+
+	return &organization, err
+}
@@ -0,0 +1,56 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOrganizationsService_List(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2021-06-07/organizations" {
+			t.Errorf("Expected /v2021-06-07/organizations path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"org-1","name":"Acme","slug":"acme"},{"id":"org-2","name":"Globex"}]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	organizations, err := client.Organizations.List(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(organizations) != 2 || organizations[0].Slug != "acme" {
+		t.Errorf("Unexpected organizations %+v", organizations)
+	}
+}
+
+func TestOrganizationsService_Get(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2021-06-07/organizations/org-1" {
+			t.Errorf("Expected /v2021-06-07/organizations/org-1 path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"org-1","name":"Acme","defaultRoleName":"member"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	organization, err := client.Organizations.Get(context.Background(), "org-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if organization.Name != "Acme" || organization.DefaultRoleName != "member" {
+		t.Errorf("Unexpected organization %+v", organization)
+	}
+}
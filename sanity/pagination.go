@@ -0,0 +1,138 @@
+package sanity
+
+import (
+	"context"
+)
+
+// Pages iterates the results of a listing one page at a time, fetching each
+// page as it is needed.
+//
+// Iterate the pages in the following manner:
+//
+//	p := client.Documents.PaginateQuery(projectId, dataset, r)
+//	for p.Next(ctx) {
+//		docs := p.Page()
+//		// ...
+//	}
+//	if err := p.Err(); err != nil {
+//		// ...
+//	}
+type Pages[T any] struct {
+	// fetch returns the page at the cursor and the cursor of the next page,
+	// which is empty if it is the last page. The cursor of the first page is
+	// empty.
+	fetch  func(ctx context.Context, cursor string) ([]T, string, error)
+	cursor string
+	page   []T
+	done   bool
+	err    error
+}
+
+// newPages returns Pages that fetch each page with `fetch`.
+func newPages[T any](fetch func(ctx context.Context, cursor string) ([]T, string, error)) *Pages[T] {
+	return &Pages[T]{fetch: fetch}
+}
+
+// singlePage returns Pages for a listing that is not paginated by the API, so
+// that it can be iterated in the same way as paginated listings.
+func singlePage[T any](fetch func(ctx context.Context) ([]T, error)) *Pages[T] {
+	return newPages(func(ctx context.Context, _ string) ([]T, string, error) {
+		items, err := fetch(ctx)
+		return items, "", err
+	})
+}
+
+// Next fetches the next non-empty page of results. It returns false when the
+// results are exhausted or an error occurs.
+func (p *Pages[T]) Next(ctx context.Context) bool {
+	for !p.done {
+		page, cursor, err := p.fetch(ctx, p.cursor)
+		if err != nil {
+			p.err = err
+			p.done = true
+			return false
+		}
+
+		p.cursor = cursor
+		if cursor == "" {
+			p.done = true
+		}
+		if len(page) > 0 {
+			p.page = page
+			return true
+		}
+	}
+
+	p.page = nil
+	return false
+}
+
+// Page returns the page of results fetched by the most recent call to Next.
+func (p *Pages[T]) Page() []T {
+	return p.page
+}
+
+// Err returns the first error encountered while fetching pages.
+func (p *Pages[T]) Err() error {
+	return p.err
+}
+
+// All fetches the remaining pages and returns their combined results.
+func (p *Pages[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for p.Next(ctx) {
+		items = append(items, p.Page()...)
+	}
+
+	return items, p.Err()
+}
+
+// Items returns an iterator over the individual results of the remaining
+// pages.
+func (p *Pages[T]) Items() *Iterator[T] {
+	return &Iterator[T]{pages: p}
+}
+
+// An Iterator iterates the results of a listing one at a time, fetching pages
+// as they are needed.
+//
+// Iterate the results in the following manner:
+//
+//	it := client.Projects.IterateMembers(projectId)
+//	for it.Next(ctx) {
+//		member := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type Iterator[T any] struct {
+	pages *Pages[T]
+	items []T
+	value T
+}
+
+// Next advances to the next result. It returns false when the results are
+// exhausted or an error occurs.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if !it.pages.Next(ctx) {
+			return false
+		}
+		it.items = it.pages.Page()
+	}
+
+	it.value = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Value returns the result at the current position of the iterator.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages.
+func (it *Iterator[T]) Err() error {
+	return it.pages.Err()
+}
@@ -0,0 +1,105 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	pages := [][]int{{1, 2}, {}, {3}}
+	p := newPages(func(ctx context.Context, cursor string) ([]int, string, error) {
+		i := len(cursor)
+		next := cursor + "."
+		if i == len(pages)-1 {
+			next = ""
+		}
+		return pages[i], next, nil
+	})
+
+	var values []int
+	it := p.Items()
+	for it.Next(context.Background()) {
+		values = append(values, it.Value())
+	}
+
+	if it.Err() != nil {
+		t.Fatalf("Expected no error, got %v", it.Err())
+	}
+	if len(values) != 3 || values[0] != 1 || values[2] != 3 {
+		t.Errorf("Expected values [1 2 3], got %v", values)
+	}
+}
+
+func TestPages_Error(t *testing.T) {
+	calls := 0
+	p := newPages(func(ctx context.Context, cursor string) ([]int, string, error) {
+		calls++
+		if calls == 2 {
+			return nil, "", errors.New("boom")
+		}
+		return []int{calls}, "next", nil
+	})
+
+	items, err := p.All(context.Background())
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("Expected error 'boom', got %v", err)
+	}
+	if len(items) != 1 {
+		t.Errorf("Expected 1 item before the error, got %v", items)
+	}
+	if p.Next(context.Background()) || calls != 2 {
+		t.Errorf("Expected no further fetches after an error, got %d calls", calls)
+	}
+}
+
+func TestProjectsService_IterateMembers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("nextCursor") == "" {
+			w.Write([]byte(`{"data":[{"sanityUserId":"u1"}],"nextCursor":"c1"}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"sanityUserId":"u2"}],"nextCursor":""}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	var ids []string
+	it := client.Projects.IterateMembers("test-project")
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().SanityUserId)
+	}
+
+	if it.Err() != nil {
+		t.Fatalf("Expected no error, got %v", it.Err())
+	}
+	if len(ids) != 2 || ids[0] != "u1" || ids[1] != "u2" {
+		t.Errorf("Expected members [u1 u2], got %v", ids)
+	}
+}
+
+func TestSchedulesService_Iterate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"schedules":[{"id":"sch-1"},{"id":"sch-2"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	count := 0
+	it := client.Schedules.Iterate("test-project", "production", nil)
+	for it.Next(context.Background()) {
+		count++
+	}
+
+	if it.Err() != nil || count != 2 {
+		t.Errorf("Expected 2 schedules, got %d (%v)", count, it.Err())
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
 	"time"
 )
@@ -119,6 +121,11 @@ func (s *ProjectsService) List(ctx context.Context) ([]Project, error) {
 	return projects, err
 }
 
+// Iterate returns an iterator over all the projects.
+func (s *ProjectsService) Iterate() *Iterator[Project] {
+	return singlePage(s.List).Items()
+}
+
 type CreateProjectRequest struct {
 	// DisplayName is the user-friendly name for the project.
 	// This is the name presented on the Sanity dashboard.
@@ -217,6 +224,45 @@ func (s *ProjectsService) Update(ctx context.Context, projectId string, r *Updat
 	return &project, err
 }
 
+type TransferProjectRequest struct {
+	// OrganizationId is the id of the organization to move the project to. If
+	// left blank, the project is moved to the personal account of the
+	// authenticated user.
+	OrganizationId string
+
+	// ConfirmProjectId must be the id of the project being moved. It guards
+	// against moving the wrong project, as the billing and members of the
+	// project change with its owner.
+	ConfirmProjectId string
+}
+
+// Transfer moves the specified project to another organization or to the
+// personal account of the authenticated user.
+//
+// The authenticated user must be an administrator of both the project and the
+// receiving organization.
+func (s *ProjectsService) Transfer(ctx context.Context, projectId string, r *TransferProjectRequest) (*Project, error) {
+	if r.ConfirmProjectId != projectId {
+		return nil, fmt.Errorf("confirmation project id '%s' does not match project '%s'", r.ConfirmProjectId, projectId)
+	}
+
+	url := fmt.Sprintf("%s/v2021-06-07/projects/%s", s.client.baseURL, projectId)
+
+	type request struct {
+		OrganizationId *string `json:"organizationId"`
+	}
+
+	req := &request{}
+	if r.OrganizationId != "" {
+		req.OrganizationId = &r.OrganizationId
+	}
+
+	var project Project
+	err := do(ctx, s.client.client, url, http.MethodPatch, req, &project)
+
+	return &project, err
+}
+
 // DeleteExternalStudioHost deletes the configured external studio host URL from the project.
 //
 // This action will appear in the project's activity feed.
@@ -338,6 +384,40 @@ type Dataset struct {
 	// If available privately, the data in the dataset is only accessible via a
 	// token.
 	AclMode string `json:"aclMode"`
+
+	// Tags are the tags assigned to the dataset.
+	Tags []DatasetTag `json:"tags,omitempty"`
+}
+
+// maxDatasetNameLength is the maximum length of dataset names.
+const maxDatasetNameLength = 64
+
+// ValidateDatasetName checks that the name follows the rules of the API for
+// dataset names, and returns a *ValidationError if it does not. Names may only
+// contain lowercase letters, digits, underscores, and dashes, must start with a
+// letter or digit, and may be at most 64 characters long.
+func ValidateDatasetName(name string) error {
+	invalid := func(reason string) error {
+		return &ValidationError{Field: "dataset name", Value: name, Reason: reason}
+	}
+
+	if name == "" {
+		return invalid("name is required")
+	}
+	if len(name) > maxDatasetNameLength {
+		return invalid(fmt.Sprintf("name must be at most %d characters", maxDatasetNameLength))
+	}
+	for i, c := range name {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+		if i == 0 && !isAlphanumeric {
+			return invalid("name must start with a lowercase letter or digit")
+		}
+		if !isAlphanumeric && c != '_' && c != '-' {
+			return invalid("name may only contain lowercase letters, digits, underscores, and dashes")
+		}
+	}
+
+	return nil
 }
 
 // ListDatasets fetches and returns all the datasets in the specified project.
@@ -350,6 +430,16 @@ func (s *ProjectsService) ListDatasets(ctx context.Context, projectId string) ([
 	return datasets, err
 }
 
+// GetDataset fetches and returns the specified dataset along with its tags.
+func (s *ProjectsService) GetDataset(ctx context.Context, projectId string, datasetName string) (*Dataset, error) {
+	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/datasets/%s", s.client.baseURL, projectId, datasetName)
+
+	var dataset Dataset
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &dataset)
+
+	return &dataset, err
+}
+
 type CreateDatasetRequest struct {
 	// Name is the name of the dataset and serves as the unique identifier for
 	// this dataset in the project.
@@ -361,21 +451,72 @@ type CreateDatasetRequest struct {
 	AclMode string `json:"aclMode,omitempty"`
 }
 
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *CreateDatasetRequest) Validate() error {
+	var v validation
+	v.add(ValidateDatasetName(r.Name))
+	v.oneOf("acl mode", r.AclMode, AclModePublic, AclModePrivate)
+
+	return v.err()
+}
+
 // CreateDataset adds a new dataset to the Sanity project.
 func (s *ProjectsService) CreateDataset(ctx context.Context, projectId string, r *CreateDatasetRequest) (*Dataset, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/datasets/%s", s.client.baseURL, projectId, r.Name)
 
-	if strings.Contains(r.Name, " ") {
-		return nil, errors.New("name cannot contain spaces")
+	type response struct {
+		Name    string `json:"datasetName"`
+		AclMode string `json:"aclMode"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodPut, r, &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dataset{Name: resp.Name, AclMode: resp.AclMode}, nil
+}
+
+type EditDatasetRequest struct {
+	// AclMode describes whether the dataset is accessible publicly or privately.
+	// Valid values are represented as the `AclMode*` constants in this
+	// package.
+	AclMode string `json:"aclMode"`
+}
+
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *EditDatasetRequest) Validate() error {
+	var v validation
+	v.require("acl mode", r.AclMode)
+	v.oneOf("acl mode", r.AclMode, AclModePublic, AclModePrivate)
+
+	return v.err()
+}
+
+// EditDataset applies the requested changes to the specified dataset, e.g., to
+// make a public dataset private.
+func (s *ProjectsService) EditDataset(ctx context.Context, projectId string, datasetName string, r *EditDatasetRequest) (*Dataset, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
 	}
 
+	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/datasets/%s", s.client.baseURL, projectId, datasetName)
+
 	type response struct {
 		Name    string `json:"datasetName"`
 		AclMode string `json:"aclMode"`
 	}
 
 	var resp response
-	err := do(ctx, s.client.client, url, http.MethodPut, r, &resp)
+	err := do(ctx, s.client.client, url, http.MethodPatch, r, &resp)
 
 	if err != nil {
 		return nil, err
@@ -392,6 +533,16 @@ type CopyDatasetRequest struct {
 	TargetDataset string `json:"targetDataset"`
 }
 
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *CopyDatasetRequest) Validate() error {
+	var v validation
+	v.require("source dataset", r.SourceDataset)
+	v.add(ValidateDatasetName(r.TargetDataset))
+
+	return v.err()
+}
+
 type CopyDatasetResponse struct {
 	Name    string `json:"datasetName"`
 	Message string `json:"message"`
@@ -404,6 +555,10 @@ type CopyDatasetResponse struct {
 // NOTE: This is enterprise feature and is only available for business and
 // enterprise plans.
 func (s *ProjectsService) CopyDataset(ctx context.Context, projectId string, r *CopyDatasetRequest) (*CopyDatasetResponse, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/datasets/%s/copy", s.client.baseURL, projectId, r.SourceDataset)
 
 	var response CopyDatasetResponse
@@ -426,6 +581,66 @@ func (s *ProjectsService) DeleteDataset(ctx context.Context, projectId string, d
 	return res.Deleted, err
 }
 
+// A DatasetStat is a usage statistic of a dataset along with the limit of the
+// plan.
+type DatasetStat struct {
+	Value int64 `json:"value"`
+	Limit int64 `json:"limit"`
+}
+
+// DatasetStats describe the usage of a dataset.
+type DatasetStats struct {
+	Documents struct {
+		// Count is the number of documents, including drafts and assets.
+		Count DatasetStat `json:"count"`
+
+		// JSONSizeSum is the total size in bytes of the documents.
+		JSONSizeSum DatasetStat `json:"jsonSizeSum"`
+	} `json:"documents"`
+
+	Fields struct {
+		// Count is the number of distinct attribute paths.
+		Count DatasetStat `json:"count"`
+	} `json:"fields"`
+
+	Types struct {
+		// Count is the number of distinct document types.
+		Count DatasetStat `json:"count"`
+	} `json:"types"`
+
+	Assets struct {
+		// Count is the number of image and file assets.
+		Count int64 `json:"count"`
+
+		// Size is the total size in bytes of the asset files.
+		Size int64 `json:"size"`
+	} `json:"assets"`
+}
+
+// GetDatasetStats fetches and returns the usage statistics of the specified
+// dataset. The asset statistics are computed with a query, as they are not
+// reported by the stats endpoint.
+func (s *ProjectsService) GetDatasetStats(ctx context.Context, projectId string, datasetName string) (*DatasetStats, error) {
+	url := fmt.Sprintf("%s/data/stats/%s", s.client.projectBaseURL(projectId), datasetName)
+
+	var stats DatasetStats
+	if err := do(ctx, s.client.client, url, http.MethodGet, nil, &stats); err != nil {
+		return nil, err
+	}
+
+	query := &QueryRequest{
+		Query: `{"count": count(*[_type in $types]), "size": math::sum(*[_type in $types].size)}`,
+		Params: map[string]any{
+			"types": []string{assetDocumentType(assetTypeImage), assetDocumentType(assetTypeFile)},
+		},
+	}
+	if err := s.client.Documents.Query(ctx, projectId, datasetName, query, &stats.Assets); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
 // -----------------------------------------------------------------------------
 // Jobs History
 
@@ -494,6 +709,65 @@ func (s *ProjectsService) ListJobsHistory(ctx context.Context, projectId string,
 	return jobs, err
 }
 
+// GetDatasetCopyJob fetches and returns the copy job with the specified id,
+// e.g., the `JobId` returned by CopyDataset.
+func (s *ProjectsService) GetDatasetCopyJob(ctx context.Context, projectId string, jobId string) (*Job, error) {
+	url := fmt.Sprintf("%s/v2022-04-01/projects/%s/datasets/copy/%s", s.client.baseURL, projectId, jobId)
+
+	var job Job
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &job)
+
+	return &job, err
+}
+
+type WaitForCopyRequest struct {
+	// PollInterval is the time between checks of the job state. Defaults to 5
+	// seconds.
+	PollInterval time.Duration
+
+	// Timeout is the maximum time to wait for the job to finish. There is no
+	// timeout if it is zero, other than the deadline of the context.
+	Timeout time.Duration
+}
+
+// WaitForCopy polls the copy job with the specified id until it finishes, and
+// returns the completed job. An error is returned if the job fails or is
+// terminated, or if it does not finish within the timeout.
+func (s *ProjectsService) WaitForCopy(ctx context.Context, projectId string, jobId string, r *WaitForCopyRequest) (*Job, error) {
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := s.GetDatasetCopyJob(ctx, projectId, jobId)
+		if err != nil {
+			return nil, err
+		}
+
+		switch job.State {
+		case JobHistoryStateCompleted:
+			return job, nil
+		case JobHistoryStateFailed, JobHistoryStateTerminated:
+			return job, fmt.Errorf("copy job %s %s", jobId, job.State)
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Features
 
@@ -609,6 +883,383 @@ func (s *ProjectsService) ListProjectRoles(ctx context.Context, projectId string
 	return roles, err
 }
 
+// -----------------------------------------------------------------------------
+// Members
+
+// A ProjectMember is a user with access to a project, as described by the
+// Access API.
+type ProjectMember struct {
+	// SanityUserId is the global identifier for the user.
+	SanityUserId string `json:"sanityUserId"`
+
+	// Profile describes the user.
+	Profile MemberProfile `json:"profile"`
+
+	// Memberships are the roles the user is assigned on the project and its
+	// resources.
+	Memberships []RoleMembership `json:"memberships"`
+}
+
+// A MemberProfile describes the user of a ProjectMember.
+type MemberProfile struct {
+	// Id is the global identifier for the user.
+	Id string `json:"id"`
+
+	// DisplayName is the user's full name.
+	DisplayName string `json:"displayName"`
+
+	// Email is the user's email address.
+	Email string `json:"email"`
+
+	// ImageURL is a url pointing to an image for the user.
+	ImageURL string `json:"imageUrl,omitempty"`
+
+	// Provider is the authentication provider of the user.
+	Provider string `json:"provider"`
+
+	// CreatedAt is the creation time of the user.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// A RoleMembership assigns roles on a resource to a member.
+type RoleMembership struct {
+	// ResourceType is the type of the resource, e.g., `project`.
+	ResourceType string `json:"resourceType"`
+
+	// ResourceId is the identifier of the resource.
+	ResourceId string `json:"resourceId"`
+
+	// RoleNames are the names of the roles assigned on the resource.
+	RoleNames []string `json:"roleNames"`
+
+	// AddedAt is the time the member was added to the resource.
+	AddedAt time.Time `json:"addedAt"`
+
+	// LastSeenAt is the last time the member accessed the resource.
+	LastSeenAt time.Time `json:"lastSeenAt"`
+}
+
+// ListMembers fetches and returns all the members of the specified project
+// along with their role memberships.
+func (s *ProjectsService) ListMembers(ctx context.Context, projectId string) ([]ProjectMember, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/users", s.client.baseURL, projectId)
+
+	return accessPages[ProjectMember](s.client.client, url).All(ctx)
+}
+
+// IterateMembers returns an iterator over the members of the specified
+// project, fetching them a page at a time.
+func (s *ProjectsService) IterateMembers(projectId string) *Iterator[ProjectMember] {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/users", s.client.baseURL, projectId)
+
+	return accessPages[ProjectMember](s.client.client, url).Items()
+}
+
+// accessPages returns the pages of a paginated Access API listing.
+func accessPages[T any](client *http.Client, baseURL string) *Pages[T] {
+	type response struct {
+		Data       []T    `json:"data"`
+		NextCursor string `json:"nextCursor"`
+	}
+
+	return newPages(func(ctx context.Context, cursor string) ([]T, string, error) {
+		params := url.Values{}
+		params.Set("limit", "100")
+		if cursor != "" {
+			params.Set("nextCursor", cursor)
+		}
+		url := baseURL + "?" + params.Encode()
+
+		var resp response
+		err := do(ctx, client, url, http.MethodGet, nil, &resp)
+
+		return resp.Data, resp.NextCursor, err
+	})
+}
+
+// GetMember fetches and returns a member of the specified project along with
+// their role memberships.
+func (s *ProjectsService) GetMember(ctx context.Context, projectId, userId string) (*ProjectMember, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/users/%s", s.client.baseURL, projectId, userId)
+
+	var member ProjectMember
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &member)
+
+	return &member, err
+}
+
+// RemoveMemberResponse describes the outcome of removing a member.
+type RemoveMemberResponse struct {
+	// Removed indicates whether the member was removed from the project.
+	Removed bool `json:"removed"`
+
+	// SanityUserId is the global identifier for the removed user.
+	SanityUserId string `json:"sanityUserId"`
+}
+
+// RemoveMember removes a user from the specified project, revoking all their
+// role memberships on the project, without additional prompt.
+//
+// Only human members are removed. To remove a robot, use DeleteRobot.
+func (s *ProjectsService) RemoveMember(ctx context.Context, projectId, userId string) (*RemoveMemberResponse, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/users/%s", s.client.baseURL, projectId, userId)
+
+	response := RemoveMemberResponse{SanityUserId: userId}
+	if err := do(ctx, s.client.client, url, http.MethodDelete, nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// A ProjectRobot is a robot with access to a project, i.e., the identity
+// behind a project token.
+type ProjectRobot struct {
+	// Id is the unique identifier for the robot.
+	Id string `json:"id"`
+
+	// Label is the label of the token the robot belongs to.
+	Label string `json:"label"`
+
+	// CreatedAt is the creation time of the robot.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Memberships are the roles the robot is assigned on the project and its
+	// resources.
+	Memberships []RoleMembership `json:"memberships"`
+}
+
+// ListRobots fetches and returns all the robot members of the specified
+// project along with their role memberships. Robots are not included in
+// ListMembers.
+func (s *ProjectsService) ListRobots(ctx context.Context, projectId string) ([]ProjectRobot, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/robots", s.client.baseURL, projectId)
+
+	return accessPages[ProjectRobot](s.client.client, url).All(ctx)
+}
+
+// IterateRobots returns an iterator over the robots of the specified project,
+// fetching them a page at a time.
+func (s *ProjectsService) IterateRobots(projectId string) *Iterator[ProjectRobot] {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/robots", s.client.baseURL, projectId)
+
+	return accessPages[ProjectRobot](s.client.client, url).Items()
+}
+
+// DeleteRobot deletes a robot member of the specified project, which also
+// revokes its token, without additional prompt.
+func (s *ProjectsService) DeleteRobot(ctx context.Context, projectId, robotId string) (bool, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/robots/%s", s.client.baseURL, projectId, robotId)
+
+	type response struct {
+		Deleted bool `json:"deleted"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodDelete, nil, &resp)
+	return resp.Deleted, err
+}
+
+// -----------------------------------------------------------------------------
+// Role permissions
+
+// A PermissionResource is a resource that permissions can be granted on with
+// the Access API, e.g., the documents of a dataset.
+type PermissionResource struct {
+	// Id is the unique identifier for the resource.
+	Id string `json:"id"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Title is the display-friendly name of the resource.
+	Title string `json:"title"`
+
+	// Description explains what the resource covers.
+	Description string `json:"description,omitempty"`
+
+	// PermissionResourceType is the type of the resource, e.g.,
+	// `sanity.document.filter`.
+	PermissionResourceType string `json:"permissionResourceType"`
+
+	// Config holds the configuration of the resource, such as the filter that
+	// selects the documents it covers.
+	Config map[string]any `json:"config,omitempty"`
+}
+
+// ListPermissionResources fetches and returns the resources that permissions
+// can be granted on in the specified project.
+func (s *ProjectsService) ListPermissionResources(ctx context.Context, projectId string) ([]PermissionResource, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/permission-resources", s.client.baseURL, projectId)
+
+	var resources []PermissionResource
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &resources)
+
+	return resources, err
+}
+
+// An AccessRole is a role as described by the Access API, along with the
+// permissions it grants.
+type AccessRole struct {
+	// Name is the name of the role and also serves as its unique identifier.
+	Name string `json:"name"`
+
+	// Title is the display-friendly name of the role.
+	Title string `json:"title"`
+
+	// Description explains the permissions associated with the role.
+	Description string `json:"description,omitempty"`
+
+	// IsCustom indicates whether the role was created for the project, as
+	// opposed to being one of the default roles created by Sanity.
+	IsCustom bool `json:"isCustom,omitempty"`
+
+	// AppliesToUsers indicates whether the role can be assigned to users.
+	AppliesToUsers bool `json:"appliesToUsers"`
+
+	// AppliesToRobots indicates whether the role can be assigned to robots,
+	// i.e., tokens.
+	AppliesToRobots bool `json:"appliesToRobots"`
+
+	// Permissions are the permissions granted by the role.
+	Permissions []RolePermission `json:"permissions"`
+}
+
+// A RolePermission grants an action on a permission resource.
+type RolePermission struct {
+	// Name is the name of the permission, e.g., `sanity-all-documents`.
+	Name string `json:"name"`
+
+	// Action is the granted action, e.g., `read`, `update`, or `publish`.
+	Action string `json:"action"`
+
+	// Params are the parameters of the permission, such as the dataset it is
+	// scoped to.
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// equal reports whether the permissions grant the same action with the same
+// parameters.
+func (p RolePermission) equal(other RolePermission) bool {
+	return p.Name == other.Name && p.Action == other.Action && reflect.DeepEqual(p.Params, other.Params)
+}
+
+// GetRole fetches and returns a role of the specified project along with the
+// permissions it grants.
+func (s *ProjectsService) GetRole(ctx context.Context, projectId, roleName string) (*AccessRole, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/roles/%s", s.client.baseURL, projectId, roleName)
+
+	var role AccessRole
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &role)
+
+	return &role, err
+}
+
+// CreateRole creates a custom role in the specified project.
+func (s *ProjectsService) CreateRole(ctx context.Context, projectId string, role *AccessRole) (*AccessRole, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/roles", s.client.baseURL, projectId)
+
+	var created AccessRole
+	err := do(ctx, s.client.client, url, http.MethodPost, role, &created)
+
+	return &created, err
+}
+
+type CloneRoleRequest struct {
+	// Name is the name of the new role.
+	Name string
+
+	// Title is the display-friendly name of the new role. If left blank, the
+	// title of the source role is used.
+	Title string
+
+	// Description explains the permissions associated with the new role. If
+	// left blank, the description of the source role is used.
+	Description string
+}
+
+// CloneRole creates a custom role in the specified project that grants the
+// same permissions as an existing role, which may be one of the default roles.
+func (s *ProjectsService) CloneRole(ctx context.Context, projectId, sourceRoleName string, r *CloneRoleRequest) (*AccessRole, error) {
+	if r.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	source, err := s.GetRole(ctx, projectId, sourceRoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &AccessRole{
+		Name:            r.Name,
+		Title:           r.Title,
+		Description:     r.Description,
+		AppliesToUsers:  source.AppliesToUsers,
+		AppliesToRobots: source.AppliesToRobots,
+		Permissions:     source.Permissions,
+	}
+	if role.Title == "" {
+		role.Title = source.Title
+	}
+	if role.Description == "" {
+		role.Description = source.Description
+	}
+
+	return s.CreateRole(ctx, projectId, role)
+}
+
+// updateRole replaces the definition of a custom role.
+func (s *ProjectsService) updateRole(ctx context.Context, projectId string, role *AccessRole) (*AccessRole, error) {
+	url := fmt.Sprintf("%s/v2025-02-19/access/project/%s/roles/%s", s.client.baseURL, projectId, role.Name)
+
+	var updated AccessRole
+	err := do(ctx, s.client.client, url, http.MethodPut, role, &updated)
+
+	return &updated, err
+}
+
+// GrantPermission adds a permission to a custom role of the specified project
+// and returns the updated role. The role is unchanged if it already grants the
+// permission.
+func (s *ProjectsService) GrantPermission(ctx context.Context, projectId, roleName string, permission RolePermission) (*AccessRole, error) {
+	role, err := s.GetRole(ctx, projectId, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range role.Permissions {
+		if p.equal(permission) {
+			return role, nil
+		}
+	}
+	role.Permissions = append(role.Permissions, permission)
+
+	return s.updateRole(ctx, projectId, role)
+}
+
+// RevokePermission removes a permission from a custom role of the specified
+// project and returns the updated role. The role is unchanged if it does not
+// grant the permission.
+func (s *ProjectsService) RevokePermission(ctx context.Context, projectId, roleName string, permission RolePermission) (*AccessRole, error) {
+	role, err := s.GetRole(ctx, projectId, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]RolePermission, 0, len(role.Permissions))
+	for _, p := range role.Permissions {
+		if !p.equal(permission) {
+			permissions = append(permissions, p)
+		}
+	}
+	if len(permissions) == len(role.Permissions) {
+		return role, nil
+	}
+	role.Permissions = permissions
+
+	return s.updateRole(ctx, projectId, role)
+}
+
 // -----------------------------------------------------------------------------
 // Tokens
 
@@ -640,13 +1291,40 @@ func (s *ProjectsService) ListProjectTokens(ctx context.Context, projectId strin
 	return tokens, err
 }
 
+// GetProjectToken fetches and returns the specified access token of the
+// project. The secret key of the token is not included.
+func (s *ProjectsService) GetProjectToken(ctx context.Context, projectId string, tokenId string) (*ProjectToken, error) {
+	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/tokens/%s", s.client.baseURL, projectId, tokenId)
+
+	var token ProjectToken
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &token)
+
+	return &token, err
+}
+
 type CreateProjectTokenRequest struct {
 	// Label is a descriptive name for the token.
 	Label string `json:"label"`
 
 	// The name of the role to assign to the token. On a free plan, it must be
 	// one of the following values: `viewer`, `editor`, or `deploy-studio`.
-	RoleName string `json:"roleName"`
+	RoleName string `json:"roleName,omitempty"`
+
+	// RoleNames are the names of the roles to assign to the token, including
+	// custom roles. Assigning multiple or custom roles requires a paid plan.
+	// It is used instead of RoleName.
+	RoleNames []string `json:"roleNames,omitempty"`
+}
+
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *CreateProjectTokenRequest) Validate() error {
+	var v validation
+	v.require("label", r.Label)
+	v.check((r.RoleName == "") != (len(r.RoleNames) == 0), "role name", r.RoleName,
+		"exactly one of RoleName and RoleNames is required")
+
+	return v.err()
 }
 
 type CreateProjectTokenResponse struct {
@@ -661,6 +1339,10 @@ type CreateProjectTokenResponse struct {
 // important to note that the `Key` value in the response can only be returned
 // from the API once, and the value should be treated as a secret value.
 func (s *ProjectsService) CreateProjectToken(ctx context.Context, projectId string, r *CreateProjectTokenRequest) (*CreateProjectTokenResponse, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/tokens", s.client.baseURL, projectId)
 
 	var response CreateProjectTokenResponse
@@ -716,6 +1398,9 @@ const (
 	ToneTransparent = "transparent"
 )
 
+// tones are the valid tones of dataset tags.
+var tones = []string{ToneDefault, TonePrimary, TonePositive, ToneCaution, ToneCritical, ToneTransparent}
+
 type CreateDatasetTagRequest struct {
 	// Name is the name of the tag and also serves as the tag's unique identifier.
 	Name string
@@ -731,14 +1416,18 @@ type CreateDatasetTagRequest struct {
 	Tone string
 }
 
-func (r *CreateDatasetTagRequest) MarshalJSON() ([]byte, error) {
-	if r.Name == "" {
-		return nil, errors.New("name is required")
-	}
-	if r.Title == "" {
-		return nil, errors.New("title is required")
-	}
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *CreateDatasetTagRequest) Validate() error {
+	var v validation
+	v.require("name", r.Name)
+	v.require("title", r.Title)
+	v.oneOf("tone", r.Tone, tones...)
 
+	return v.err()
+}
+
+func (r *CreateDatasetTagRequest) MarshalJSON() ([]byte, error) {
 	type request struct {
 		Name        string            `json:"name"`
 		Title       string            `json:"title"`
@@ -761,6 +1450,10 @@ func (r *CreateDatasetTagRequest) MarshalJSON() ([]byte, error) {
 
 // CreateDatasetTag creates and returns a new tag.
 func (s *ProjectsService) CreateDatasetTag(ctx context.Context, projectId string, r *CreateDatasetTagRequest) (*DatasetTag, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/tags", s.client.baseURL, projectId)
 
 	var tag DatasetTag
@@ -784,6 +1477,15 @@ type EditDatasetTagRequest struct {
 	Tone string
 }
 
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *EditDatasetTagRequest) Validate() error {
+	var v validation
+	v.oneOf("tone", r.Tone, tones...)
+
+	return v.err()
+}
+
 func (r *EditDatasetTagRequest) MarshalJSON() ([]byte, error) {
 	type request struct {
 		Name        string            `json:"name"`
@@ -807,6 +1509,10 @@ func (r *EditDatasetTagRequest) MarshalJSON() ([]byte, error) {
 
 // EditDatasetTag updates and returns the specified tag.
 func (s *ProjectsService) EditDatasetTag(ctx context.Context, projectId, tagIdentifier string, r *EditDatasetTagRequest) (*DatasetTag, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/v2021-06-07/projects/%s/tags/%s", s.client.baseURL, projectId, tagIdentifier)
 
 	var tag DatasetTag
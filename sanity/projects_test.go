@@ -0,0 +1,546 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProjectsService_Transfer(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected PATCH method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2021-06-07/projects/test-project" {
+			t.Errorf("Expected /v2021-06-07/projects/test-project path, got %s", r.URL.Path)
+		}
+
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"test-project","organizationId":"org-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	project, err := client.Projects.Transfer(context.Background(), "test-project", &TransferProjectRequest{
+		OrganizationId:   "org-1",
+		ConfirmProjectId: "test-project",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if body["organizationId"] != "org-1" || project.OrganizationId != "org-1" {
+		t.Errorf("Unexpected body %v and project %+v", body, project)
+	}
+
+	// Moving to the personal account sends a null organization.
+	if _, err := client.Projects.Transfer(context.Background(), "test-project", &TransferProjectRequest{ConfirmProjectId: "test-project"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if v, ok := body["organizationId"]; !ok || v != nil {
+		t.Errorf("Expected a null organizationId, got %v", body)
+	}
+}
+
+func TestProjectsService_Transfer_Unconfirmed(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.Projects.Transfer(context.Background(), "test-project", &TransferProjectRequest{OrganizationId: "org-1"})
+	if err == nil {
+		t.Error("Expected an error for a missing confirmation")
+	}
+}
+
+func TestProjectsService_ListMembers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2025-02-19/access/project/test-project/users" {
+			t.Errorf("Expected /v2025-02-19/access/project/test-project/users path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("nextCursor") {
+		case "":
+			w.Write([]byte(`{"data":[{"sanityUserId":"user-1","profile":{"id":"user-1","displayName":"Ada Lovelace"},"memberships":[{"resourceType":"project","resourceId":"test-project","roleNames":["administrator"]}]}],"nextCursor":"page-2"}`))
+		case "page-2":
+			w.Write([]byte(`{"data":[{"sanityUserId":"user-2","profile":{"id":"user-2","displayName":"Alan Turing"}}]}`))
+		default:
+			t.Errorf("Unexpected cursor %s", r.URL.RawQuery)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	members, err := client.Projects.ListMembers(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 members, got %d", len(members))
+	}
+	if members[0].Profile.DisplayName != "Ada Lovelace" || members[0].Memberships[0].RoleNames[0] != "administrator" {
+		t.Errorf("Unexpected member %+v", members[0])
+	}
+	if members[1].SanityUserId != "user-2" {
+		t.Errorf("Unexpected member %+v", members[1])
+	}
+}
+
+func TestProjectsService_GetMember(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2025-02-19/access/project/test-project/users/user-1" {
+			t.Errorf("Expected /v2025-02-19/access/project/test-project/users/user-1 path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"sanityUserId":"user-1","profile":{"id":"user-1","email":"ada@example.com"}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	member, err := client.Projects.GetMember(context.Background(), "test-project", "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if member.Profile.Email != "ada@example.com" {
+		t.Errorf("Unexpected member %+v", member)
+	}
+}
+
+func TestProjectsService_RemoveMember(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2025-02-19/access/project/test-project/users/user-1" {
+			t.Errorf("Expected /v2025-02-19/access/project/test-project/users/user-1 path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"removed":true}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	resp, err := client.Projects.RemoveMember(context.Background(), "test-project", "user-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !resp.Removed || resp.SanityUserId != "user-1" {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+}
+
+func TestProjectsService_ListRobots(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2025-02-19/access/project/test-project/robots" {
+			t.Errorf("Expected /v2025-02-19/access/project/test-project/robots path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"id":"robot-1","label":"CI","memberships":[{"resourceType":"project","resourceId":"test-project","roleNames":["deploy-studio"]}]}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	robots, err := client.Projects.ListRobots(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(robots) != 1 || robots[0].Label != "CI" || robots[0].Memberships[0].RoleNames[0] != "deploy-studio" {
+		t.Errorf("Unexpected robots %+v", robots)
+	}
+}
+
+func TestProjectsService_DeleteRobot(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2025-02-19/access/project/test-project/robots/robot-1" {
+			t.Errorf("Expected /v2025-02-19/access/project/test-project/robots/robot-1 path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"deleted":true}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	deleted, err := client.Projects.DeleteRobot(context.Background(), "test-project", "robot-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !deleted {
+		t.Error("Expected the robot to be deleted")
+	}
+}
+
+func TestProjectsService_ListPermissionResources(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2025-02-19/access/project/test-project/permission-resources" {
+			t.Errorf("Expected /v2025-02-19/access/project/test-project/permission-resources path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"res-1","name":"sanity-all-documents","title":"All documents","permissionResourceType":"sanity.document.filter"}]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	resources, err := client.Projects.ListPermissionResources(context.Background(), "test-project")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resources) != 1 || resources[0].Name != "sanity-all-documents" {
+		t.Errorf("Unexpected resources %+v", resources)
+	}
+}
+
+func TestProjectsService_GrantAndRevokePermission(t *testing.T) {
+	role := AccessRole{
+		Name:        "reviewer",
+		Title:       "Reviewer",
+		IsCustom:    true,
+		Permissions: []RolePermission{{Name: "sanity-all-documents", Action: "read", Params: map[string]any{"dataset": "production"}}},
+	}
+	updates := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2025-02-19/access/project/test-project/roles/reviewer" {
+			t.Errorf("Expected /v2025-02-19/access/project/test-project/roles/reviewer path, got %s", r.URL.Path)
+		}
+
+		if r.Method == http.MethodPut {
+			updates++
+			json.NewDecoder(r.Body).Decode(&role)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(role)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	publish := RolePermission{Name: "sanity-all-documents", Action: "publish", Params: map[string]any{"dataset": "production"}}
+	updated, err := client.Projects.GrantPermission(context.Background(), "test-project", "reviewer", publish)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(updated.Permissions) != 2 {
+		t.Errorf("Expected 2 permissions after grant, got %+v", updated.Permissions)
+	}
+
+	// Granting the permission again does not update the role.
+	if _, err := client.Projects.GrantPermission(context.Background(), "test-project", "reviewer", publish); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("Expected 1 update, got %d", updates)
+	}
+
+	updated, err = client.Projects.RevokePermission(context.Background(), "test-project", "reviewer", publish)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(updated.Permissions) != 1 || updated.Permissions[0].Action != "read" {
+		t.Errorf("Expected only the read permission after revoke, got %+v", updated.Permissions)
+	}
+}
+
+func TestProjectsService_CloneRole(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2025-02-19/access/project/test-project/roles/editor":
+			w.Write([]byte(`{"name":"editor","title":"Editor","appliesToUsers":true,"permissions":[{"name":"sanity-all-documents","action":"publish"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2025-02-19/access/project/test-project/roles":
+			var role AccessRole
+			json.NewDecoder(r.Body).Decode(&role)
+			if role.Name != "translator" || role.Title != "Editor" || !role.AppliesToUsers || len(role.Permissions) != 1 {
+				t.Errorf("Unexpected role %+v", role)
+			}
+			role.IsCustom = true
+			json.NewEncoder(w).Encode(role)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	role, err := client.Projects.CloneRole(context.Background(), "test-project", "editor", &CloneRoleRequest{Name: "translator"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if role.Name != "translator" || !role.IsCustom {
+		t.Errorf("Unexpected role %+v", role)
+	}
+}
+
+func TestProjectsService_GetProjectToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2021-06-07/projects/test-project/tokens/token-1" {
+			t.Errorf("Expected /v2021-06-07/projects/test-project/tokens/token-1 path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"token-1","label":"CI","projectUserId":"robot-1","createdAt":"2024-01-02T00:00:00Z","roles":[{"name":"editor","title":"Editor"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	token, err := client.Projects.GetProjectToken(context.Background(), "test-project", "token-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if token.Label != "CI" || len(token.Roles) != 1 || token.Roles[0].Name != "editor" || token.CreatedAt.IsZero() {
+		t.Errorf("Unexpected token %+v", token)
+	}
+}
+
+func TestProjectsService_CreateProjectToken_RoleNames(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["roleName"]; ok {
+			t.Errorf("Expected no roleName, got %v", body)
+		}
+		if roles, _ := body["roleNames"].([]any); len(roles) != 2 || roles[1] != "translator" {
+			t.Errorf("Unexpected roleNames in %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"token-1","label":"CI","key":"secret","roles":[{"name":"viewer"},{"name":"translator"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	token, err := client.Projects.CreateProjectToken(context.Background(), "test-project", &CreateProjectTokenRequest{
+		Label:     "CI",
+		RoleNames: []string{"viewer", "translator"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if token.Key != "secret" || len(token.Roles) != 2 {
+		t.Errorf("Unexpected token %+v", token)
+	}
+
+	_, err = client.Projects.CreateProjectToken(context.Background(), "test-project", &CreateProjectTokenRequest{Label: "CI"})
+	if err == nil {
+		t.Error("Expected an error for a missing role")
+	}
+}
+
+func TestProjectsService_WaitForCopy(t *testing.T) {
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2022-04-01/projects/test-project/datasets/copy/job-1" {
+			t.Errorf("Expected /v2022-04-01/projects/test-project/datasets/copy/job-1 path, got %s", r.URL.Path)
+		}
+
+		polls++
+		state := JobHistoryStateRunning
+		if polls == 3 {
+			state = JobHistoryStateCompleted
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"job-1","state":"` + state + `","targetDataset":"staging"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	job, err := client.Projects.WaitForCopy(context.Background(), "test-project", "job-1", &WaitForCopyRequest{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if job.State != JobHistoryStateCompleted || polls != 3 {
+		t.Errorf("Expected a completed job after 3 polls, got %s after %d", job.State, polls)
+	}
+}
+
+func TestProjectsService_WaitForCopy_Failed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"job-1","state":"failed"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	_, err := client.Projects.WaitForCopy(context.Background(), "test-project", "job-1", &WaitForCopyRequest{PollInterval: time.Millisecond})
+	if err == nil {
+		t.Error("Expected an error for a failed job")
+	}
+}
+
+func TestProjectsService_EditDataset(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected PATCH method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2021-06-07/projects/test-project/datasets/production" {
+			t.Errorf("Expected /v2021-06-07/projects/test-project/datasets/production path, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["aclMode"] != AclModePrivate {
+			t.Errorf("Expected private acl mode, got %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"datasetName":"production","aclMode":"private"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	dataset, err := client.Projects.EditDataset(context.Background(), "test-project", "production", &EditDatasetRequest{AclMode: AclModePrivate})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if dataset.Name != "production" || dataset.AclMode != AclModePrivate {
+		t.Errorf("Unexpected dataset %+v", dataset)
+	}
+}
+
+func TestProjectsService_GetDatasetStats(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/data/stats/production":
+			w.Write([]byte(`{"documents":{"count":{"value":120,"limit":10000},"jsonSizeSum":{"value":52000,"limit":10000000}},"fields":{"count":{"value":40,"limit":2000}},"types":{"count":{"value":5,"limit":1000}}}`))
+		case "/data/query/production":
+			w.Write([]byte(`{"result":{"count":3,"size":2048}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	stats, err := client.Projects.GetDatasetStats(context.Background(), "test-project", "production")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.Documents.Count.Value != 120 || stats.Documents.JSONSizeSum.Limit != 10000000 || stats.Types.Count.Value != 5 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if stats.Assets.Count != 3 || stats.Assets.Size != 2048 {
+		t.Errorf("Unexpected asset stats %+v", stats.Assets)
+	}
+}
+
+func TestProjectsService_GetDataset(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2021-06-07/projects/test-project/datasets/production" {
+			t.Errorf("Expected /v2021-06-07/projects/test-project/datasets/production path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"production","aclMode":"public","tags":[{"name":"live","title":"Live"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	dataset, err := client.Projects.GetDataset(context.Background(), "test-project", "production")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if dataset.AclMode != AclModePublic || len(dataset.Tags) != 1 || dataset.Tags[0].Name != "live" {
+		t.Errorf("Unexpected dataset %+v", dataset)
+	}
+}
+
+func TestValidateDatasetName(t *testing.T) {
+	tests := map[string]bool{
+		"production":            true,
+		"staging-2024_q1":       true,
+		"0day":                  true,
+		"":                      false,
+		"Production":            false,
+		"my dataset":            false,
+		"-production":           false,
+		"_production":           false,
+		"prod.backup":           false,
+		strings.Repeat("a", 64): true,
+		strings.Repeat("a", 65): false,
+	}
+
+	for name, valid := range tests {
+		err := ValidateDatasetName(name)
+		if valid && err != nil {
+			t.Errorf("Expected '%s' to be valid, got %v", name, err)
+		}
+		if !valid {
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Expected a ValidationError for '%s', got %v", name, err)
+			}
+		}
+	}
+}
+
+func TestProjectsService_CreateDataset_InvalidName(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.Projects.CreateDataset(context.Background(), "test-project", &CreateDatasetRequest{Name: "My Dataset"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "dataset name" {
+		t.Errorf("Expected a ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package sanity
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ReleasesService is a client for content releases, which bundle versions of
+// documents that are published together.
+//
+// Releases are managed with the Actions API and stored as documents in the
+// dataset.
+//
+// Refer to https://www.sanity.io/docs/content-releases for more information.
+type ReleasesService service
+
+const (
+	ReleaseTypeASAP      = "asap"
+	ReleaseTypeScheduled = "scheduled"
+	ReleaseTypeUndecided = "undecided"
+)
+
+const (
+	ReleaseStateActive    = "active"
+	ReleaseStateScheduled = "scheduled"
+	ReleaseStatePublished = "published"
+	ReleaseStateArchived  = "archived"
+)
+
+// A Release is a bundle of document versions that are published together.
+type Release struct {
+	// Id is the identifier of the release document, e.g., `_.releases.<Name>`.
+	Id string `json:"_id"`
+
+	// Name is the identifier of the release, which is used in the identifiers
+	// of its version documents.
+	Name string `json:"name"`
+
+	// State is the state of the release. Valid values include the
+	// `ReleaseState*` constants in this package, as well as transitional
+	// states such as `publishing`.
+	State string `json:"state"`
+
+	// Metadata describes the release.
+	Metadata ReleaseMetadata `json:"metadata"`
+
+	// PublishAt is the time a scheduled release is published.
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+
+	// CreatedAt is the time the release was created.
+	CreatedAt time.Time `json:"_createdAt"`
+
+	// UpdatedAt is the time the release was last updated.
+	UpdatedAt time.Time `json:"_updatedAt"`
+}
+
+// ReleaseMetadata describes a release.
+type ReleaseMetadata struct {
+	// Title is the human-readable title of the release.
+	Title string `json:"title,omitempty"`
+
+	// Description is a human-readable description of the release.
+	Description string `json:"description,omitempty"`
+
+	// ReleaseType indicates when the release is intended to be published.
+	// Valid values are represented as the `ReleaseType*` constants in this
+	// package.
+	ReleaseType string `json:"releaseType,omitempty"`
+
+	// IntendedPublishAt is the time the release is intended to be published.
+	// It is informational and does not schedule the release.
+	IntendedPublishAt *time.Time `json:"intendedPublishAt,omitempty"`
+}
+
+// CreateReleaseRequest describes a release to create.
+type CreateReleaseRequest struct {
+	// ReleaseId is the identifier of the release. If left blank, an identifier
+	// is generated.
+	ReleaseId string
+
+	// Metadata describes the release.
+	Metadata ReleaseMetadata
+}
+
+// CreateReleaseResponse describes a created release.
+type CreateReleaseResponse struct {
+	// ReleaseId is the identifier of the release.
+	ReleaseId string
+
+	// TransactionId is the identifier of the transaction that created the
+	// release.
+	TransactionId string
+}
+
+// Create creates a release. If no release type is specified, the release type
+// is ReleaseTypeScheduled when an intended publish time is set, and
+// ReleaseTypeUndecided otherwise.
+func (s *ReleasesService) Create(ctx context.Context, projectId, dataset string, r *CreateReleaseRequest) (*CreateReleaseResponse, error) {
+	releaseId := r.ReleaseId
+	if releaseId == "" {
+		id, err := newReleaseId()
+		if err != nil {
+			return nil, err
+		}
+		releaseId = id
+	}
+
+	metadata := r.Metadata
+	if metadata.ReleaseType == "" {
+		metadata.ReleaseType = ReleaseTypeUndecided
+		if metadata.IntendedPublishAt != nil {
+			metadata.ReleaseType = ReleaseTypeScheduled
+		}
+	}
+
+	resp, err := s.client.Actions.Apply(ctx, projectId, dataset, &ApplyActionsRequest{
+		Actions: []Action{CreateReleaseAction{ReleaseId: releaseId, Metadata: &metadata}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateReleaseResponse{ReleaseId: releaseId, TransactionId: resp.TransactionId}, nil
+}
+
+// releaseIdAlphabet contains the characters of generated release identifiers.
+const releaseIdAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// newReleaseId generates a random release identifier in the same format as the
+// studio, i.e., `r` followed by eight alphanumeric characters.
+func newReleaseId() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	id := []byte{'r'}
+	for _, c := range b {
+		id = append(id, releaseIdAlphabet[int(c)%len(releaseIdAlphabet)])
+	}
+
+	return string(id), nil
+}
+
+// ListReleasesRequest describes the releases to list.
+type ListReleasesRequest struct {
+	// State limits the results to releases in the state. Valid values are
+	// represented as the `ReleaseState*` constants in this package.
+	State string
+}
+
+// List returns the releases of the dataset that match the request, newest
+// first. The request may be nil to list all releases.
+func (s *ReleasesService) List(ctx context.Context, projectId, dataset string, r *ListReleasesRequest) ([]Release, error) {
+	q := &QueryRequest{Query: "releases::all() | order(_createdAt desc)"}
+	if r != nil && r.State != "" {
+		q.Query = "releases::all()[state == $state] | order(_createdAt desc)"
+		q.Params = map[string]any{"state": r.State}
+	}
+
+	var releases []Release
+	err := s.client.Documents.Query(ctx, projectId, dataset, q, &releases)
+
+	return releases, err
+}
+
+// Documents returns the version documents contained in the release.
+func (s *ReleasesService) Documents(ctx context.Context, projectId, dataset, releaseId string) ([]Document, error) {
+	var docs []Document
+	err := s.client.Documents.Query(ctx, projectId, dataset, &QueryRequest{
+		Query:       "*[_id in path($path)] | order(_id asc)",
+		Params:      map[string]any{"path": "versions." + releaseId + ".**"},
+		Perspective: PerspectiveRaw,
+	}, &docs)
+
+	return docs, err
+}
+
+// Publish publishes all the version documents of the release at once.
+func (s *ReleasesService) Publish(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error) {
+	return s.apply(ctx, projectId, dataset, PublishReleaseAction{ReleaseId: releaseId})
+}
+
+// Schedule schedules the release to be published at the given time.
+func (s *ReleasesService) Schedule(ctx context.Context, projectId, dataset, releaseId string, publishAt time.Time) (*ApplyActionsResponse, error) {
+	return s.apply(ctx, projectId, dataset, ScheduleReleaseAction{ReleaseId: releaseId, PublishAt: publishAt})
+}
+
+// Unschedule cancels the scheduled publishing of the release, returning it to
+// the ReleaseStateActive state.
+func (s *ReleasesService) Unschedule(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error) {
+	return s.apply(ctx, projectId, dataset, UnscheduleReleaseAction{ReleaseId: releaseId})
+}
+
+// Archive archives the release.
+func (s *ReleasesService) Archive(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error) {
+	return s.apply(ctx, projectId, dataset, ArchiveReleaseAction{ReleaseId: releaseId})
+}
+
+// Unarchive restores an archived release.
+func (s *ReleasesService) Unarchive(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error) {
+	return s.apply(ctx, projectId, dataset, UnarchiveReleaseAction{ReleaseId: releaseId})
+}
+
+// Delete deletes the release. Only archived and published releases can be
+// deleted.
+func (s *ReleasesService) Delete(ctx context.Context, projectId, dataset, releaseId string) (*ApplyActionsResponse, error) {
+	return s.apply(ctx, projectId, dataset, DeleteReleaseAction{ReleaseId: releaseId})
+}
+
+// apply applies a single release action.
+func (s *ReleasesService) apply(ctx context.Context, projectId, dataset string, action Action) (*ApplyActionsResponse, error) {
+	return s.client.Actions.Apply(ctx, projectId, dataset, &ApplyActionsRequest{Actions: []Action{action}})
+}
+
+// VersionId returns the identifier of the version document of a document in a
+// release, i.e., `versions.<releaseId>.<publishedId>`. A `drafts.` prefix on
+// the document identifier is ignored.
+func VersionId(releaseId, docId string) string {
+	return "versions." + releaseId + "." + strings.TrimPrefix(docId, "drafts.")
+}
+
+// ParseVersionId returns the release identifier and the published document
+// identifier of a version document identifier. It reports false if the
+// identifier is not a version document identifier.
+func ParseVersionId(versionId string) (releaseId, publishedId string, ok bool) {
+	if !strings.HasPrefix(versionId, "versions.") {
+		return "", "", false
+	}
+
+	releaseId, publishedId, ok = strings.Cut(strings.TrimPrefix(versionId, "versions."), ".")
+	if !ok || releaseId == "" || publishedId == "" {
+		return "", "", false
+	}
+
+	return releaseId, publishedId, true
+}
+
+// ReleasePerspective returns a query perspective that shows documents as they
+// will be once the releases are published, e.g., to preview a release before
+// it ships. The releases are stacked in order of precedence, with the first
+// release taking precedence over the others, and drafts are stacked beneath
+// them, so documents without a version in any release are shown as their
+// drafts, or as published if they have no draft.
+//
+//	err := client.Documents.Query(ctx, projectId, dataset, &QueryRequest{
+//		Query:       `*[_type == "post"]`,
+//		Perspective: ReleasePerspective("rSpring"),
+//	}, &posts)
+func ReleasePerspective(releaseIds ...string) string {
+	return strings.Join(append(append([]string{}, releaseIds...), PerspectiveDrafts), ",")
+}
+
+// CreateVersion adds a version of a document to the release. The `_id` of the
+// document is its published identifier, and it is replaced with the version
+// identifier.
+func (s *ReleasesService) CreateVersion(ctx context.Context, projectId, dataset, releaseId string, doc Document) (*ApplyActionsResponse, error) {
+	publishedId := strings.TrimPrefix(doc.Id(), "drafts.")
+	if publishedId == "" {
+		return nil, errors.New("document id is required")
+	}
+
+	version := make(Document, len(doc))
+	for k, v := range doc {
+		version[k] = v
+	}
+	version["_id"] = VersionId(releaseId, publishedId)
+
+	return s.apply(ctx, projectId, dataset, CreateVersionAction{PublishedId: publishedId, Document: version})
+}
+
+// DiscardVersion removes the version of a document from the release. The
+// published document and its draft are unaffected.
+func (s *ReleasesService) DiscardVersion(ctx context.Context, projectId, dataset, releaseId, docId string) (*ApplyActionsResponse, error) {
+	return s.apply(ctx, projectId, dataset, DiscardVersionAction{VersionId: VersionId(releaseId, docId)})
+}
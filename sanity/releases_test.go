@@ -0,0 +1,280 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestReleasesService_Create(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/actions/production" {
+			t.Errorf("Expected /data/actions/production path, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			Actions []map[string]any `json:"actions"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if len(body.Actions) != 1 {
+			t.Fatalf("Expected 1 action, got %d", len(body.Actions))
+		}
+		action := body.Actions[0]
+		if action["actionType"] != ActionTypeCreateRelease || action["releaseId"] != "rSpring" {
+			t.Errorf("Unexpected action %v", action)
+		}
+		metadata, _ := action["metadata"].(map[string]any)
+		if metadata["title"] != "Spring launch" || metadata["releaseType"] != ReleaseTypeScheduled || metadata["intendedPublishAt"] != "2024-03-20T08:00:00Z" {
+			t.Errorf("Unexpected metadata %v", metadata)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	publishAt := time.Date(2024, 3, 20, 8, 0, 0, 0, time.UTC)
+	resp, err := client.Releases.Create(context.Background(), "test-project", "production", &CreateReleaseRequest{
+		ReleaseId: "rSpring",
+		Metadata: ReleaseMetadata{
+			Title:             "Spring launch",
+			IntendedPublishAt: &publishAt,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.ReleaseId != "rSpring" || resp.TransactionId != "tx-1" {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+}
+
+func TestNewReleaseId(t *testing.T) {
+	id, err := newReleaseId()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !regexp.MustCompile(`^r[a-zA-Z0-9]{8}$`).MatchString(id) {
+		t.Errorf("Unexpected release ID '%s'", id)
+	}
+}
+
+func TestReleasesService_List(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Query != "releases::all()[state == $state] | order(_createdAt desc)" || req.Params["state"] != ReleaseStateActive {
+			t.Errorf("Unexpected query '%s' with params %v", req.Query, req.Params)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"_id":"_.releases.rSpring","name":"rSpring","state":"active","metadata":{"title":"Spring launch","releaseType":"asap"}}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	releases, err := client.Releases.List(context.Background(), "test-project", "production", &ListReleasesRequest{State: ReleaseStateActive})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(releases) != 1 || releases[0].Name != "rSpring" || releases[0].Metadata.Title != "Spring launch" {
+		t.Errorf("Unexpected releases %+v", releases)
+	}
+}
+
+func TestReleasesService_Documents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("perspective") != PerspectiveRaw {
+			t.Errorf("Expected raw perspective, got %s", r.URL.RawQuery)
+		}
+
+		var req QueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Params["path"] != "versions.rSpring.**" {
+			t.Errorf("Unexpected params %v", req.Params)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"_id":"versions.rSpring.post_1","_type":"post"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	docs, err := client.Releases.Documents(context.Background(), "test-project", "production", "rSpring")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(docs) != 1 || docs[0].Id() != "versions.rSpring.post_1" {
+		t.Errorf("Unexpected documents %v", docs)
+	}
+}
+
+func TestReleasesService_Lifecycle(t *testing.T) {
+	var actions []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Actions []map[string]any `json:"actions"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		actions = append(actions, body.Actions...)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	ctx := context.Background()
+	calls := []func() (*ApplyActionsResponse, error){
+		func() (*ApplyActionsResponse, error) {
+			return client.Releases.Schedule(ctx, "test-project", "production", "rSpring", time.Date(2024, 3, 20, 8, 0, 0, 0, time.UTC))
+		},
+		func() (*ApplyActionsResponse, error) {
+			return client.Releases.Unschedule(ctx, "test-project", "production", "rSpring")
+		},
+		func() (*ApplyActionsResponse, error) {
+			return client.Releases.Publish(ctx, "test-project", "production", "rSpring")
+		},
+		func() (*ApplyActionsResponse, error) {
+			return client.Releases.Archive(ctx, "test-project", "production", "rSpring")
+		},
+		func() (*ApplyActionsResponse, error) {
+			return client.Releases.Unarchive(ctx, "test-project", "production", "rSpring")
+		},
+		func() (*ApplyActionsResponse, error) {
+			return client.Releases.Delete(ctx, "test-project", "production", "rSpring")
+		},
+	}
+	for i, call := range calls {
+		resp, err := call()
+		if err != nil {
+			t.Fatalf("Call %d: expected no error, got %v", i, err)
+		}
+		if resp.TransactionId != "tx-1" {
+			t.Errorf("Call %d: expected transaction ID 'tx-1', got '%s'", i, resp.TransactionId)
+		}
+	}
+
+	expected := []string{
+		ActionTypeScheduleRelease,
+		ActionTypeUnscheduleRelease,
+		ActionTypePublishRelease,
+		ActionTypeArchiveRelease,
+		ActionTypeUnarchiveRelease,
+		ActionTypeDeleteRelease,
+	}
+	if len(actions) != len(expected) {
+		t.Fatalf("Expected %d actions, got %d", len(expected), len(actions))
+	}
+	for i, action := range actions {
+		if action["actionType"] != expected[i] || action["releaseId"] != "rSpring" {
+			t.Errorf("Action %d: unexpected action %v", i, action)
+		}
+	}
+	if actions[0]["publishAt"] != "2024-03-20T08:00:00Z" {
+		t.Errorf("Expected publishAt on the schedule action, got %v", actions[0])
+	}
+}
+
+func TestVersionId(t *testing.T) {
+	if id := VersionId("rSpring", "drafts.post_1"); id != "versions.rSpring.post_1" {
+		t.Errorf("Expected 'versions.rSpring.post_1', got '%s'", id)
+	}
+
+	releaseId, publishedId, ok := ParseVersionId("versions.rSpring.post.1")
+	if !ok || releaseId != "rSpring" || publishedId != "post.1" {
+		t.Errorf("Unexpected parse result '%s', '%s', %v", releaseId, publishedId, ok)
+	}
+
+	for _, id := range []string{"post_1", "drafts.post_1", "versions.rSpring", "versions..post_1"} {
+		if _, _, ok := ParseVersionId(id); ok {
+			t.Errorf("Expected '%s' not to be a version ID", id)
+		}
+	}
+}
+
+func TestReleasesService_CreateAndDiscardVersion(t *testing.T) {
+	var actions []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Actions []map[string]any `json:"actions"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		actions = append(actions, body.Actions...)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	doc := Document{"_id": "post_1", "_type": "post", "title": "Spring"}
+	if _, err := client.Releases.CreateVersion(context.Background(), "test-project", "production", "rSpring", doc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Releases.DiscardVersion(context.Background(), "test-project", "production", "rSpring", "post_1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if doc.Id() != "post_1" {
+		t.Errorf("Expected the document not to be modified, got ID '%s'", doc.Id())
+	}
+	if len(actions) != 2 {
+		t.Fatalf("Expected 2 actions, got %d", len(actions))
+	}
+	version, _ := actions[0]["document"].(map[string]any)
+	if actions[0]["actionType"] != ActionTypeCreateVersion || actions[0]["publishedId"] != "post_1" || version["_id"] != "versions.rSpring.post_1" || version["title"] != "Spring" {
+		t.Errorf("Unexpected create action %v", actions[0])
+	}
+	if actions[1]["actionType"] != ActionTypeDiscardVersion || actions[1]["versionId"] != "versions.rSpring.post_1" {
+		t.Errorf("Unexpected discard action %v", actions[1])
+	}
+}
+
+func TestReleasePerspective(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Query().Get("perspective"); p != "rSummer,rSpring,drafts" {
+			t.Errorf("Expected perspective 'rSummer,rSpring,drafts', got '%s'", p)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[{"_id":"post_1","title":"Summer"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	p := client.Documents.PaginateQuery("test-project", "production", &PaginateQueryRequest{
+		Query:       `*[_type == "post"]`,
+		Perspective: ReleasePerspective("rSummer", "rSpring"),
+	})
+	if !p.Next(context.Background()) {
+		t.Fatalf("Expected a page, got error %v", p.Err())
+	}
+
+	if docs := p.Page(); len(docs) != 1 || docs[0]["title"] != "Summer" {
+		t.Errorf("Unexpected page %v", docs)
+	}
+}
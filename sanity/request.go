@@ -0,0 +1,108 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrNotSent is returned by the functions of the client when they are called
+// with a context from WithoutSending, once the request has been built.
+var ErrNotSent = errors.New("sanity: request not sent")
+
+type requestKey struct{}
+
+// WithoutSending returns a context that makes the client build the HTTP
+// request for an operation without sending it. The request is stored into
+// `req`, and the operation returns ErrNotSent. It makes it possible to
+// inspect, sign, or enqueue the requests of any operation, e.g., into an
+// outbox:
+//
+//	var req *http.Request
+//	_, err := client.Documents.Mutate(sanity.WithoutSending(ctx, &req), projectId, dataset, r)
+//	if !errors.Is(err, sanity.ErrNotSent) {
+//		return err
+//	}
+//	outbox.Enqueue(req)
+//
+// The request is built as it would be sent by the client, including its body
+// and `User-Agent` header, but before the transport of the HTTP client given
+// to NewClient, which usually provides authentication. The context of the
+// request no longer carries the value added by WithoutSending, so the request
+// may be sent with the same client later.
+//
+// Requests are validated before they are built, and operations that make
+// several requests stop at the first one.
+func WithoutSending(ctx context.Context, req **http.Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, req)
+}
+
+// sendingContext hides the value added by WithoutSending from a context.
+type sendingContext struct {
+	context.Context
+}
+
+func (c sendingContext) Value(key any) any {
+	if key == (requestKey{}) {
+		return nil
+	}
+
+	return c.Context.Value(key)
+}
+
+// captureRequest stores the request into the destination given to
+// WithoutSending, if the context of the request has one, and reports whether
+// it did.
+func captureRequest(req *http.Request) bool {
+	dst, ok := req.Context().Value(requestKey{}).(**http.Request)
+	if !ok || dst == nil {
+		return false
+	}
+
+	captured := req.Clone(sendingContext{req.Context()})
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			captured.Body = body
+		}
+	}
+	*dst = captured
+
+	return true
+}
+
+type headerKey struct{}
+
+// WithHeader returns a context that makes the client add the header to the
+// requests made with the context, e.g., for debugging or routing by a gateway:
+//
+//	ctx = sanity.WithHeader(ctx, "X-Debug", "1")
+//	doc, err := client.Documents.Get(ctx, projectId, dataset, id)
+//
+// The header replaces any value set by the client for the same key. Headers
+// added by nested contexts are combined, and adding the same key again adds
+// another value.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	header := http.Header{}
+	if parent, ok := ctx.Value(headerKey{}).(http.Header); ok {
+		header = parent.Clone()
+	}
+	header.Add(key, value)
+
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+// addHeaders returns the request with the headers of its context added, if
+// any.
+func addHeaders(req *http.Request) *http.Request {
+	header, ok := req.Context().Value(headerKey{}).(http.Header)
+	if !ok {
+		return req
+	}
+
+	req = req.Clone(req.Context())
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	return req
+}
@@ -0,0 +1,89 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithoutSending(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"transactionId":"tx1","results":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	var req *http.Request
+	ctx := WithoutSending(context.Background(), &req)
+	_, err := client.Documents.Mutate(ctx, "test-project", "production", &MutateRequest{
+		Mutations: []Mutation{{Delete: &DeleteMutation{Id: "a"}}},
+	})
+	if !errors.Is(err, ErrNotSent) {
+		t.Fatalf("Expected ErrNotSent, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests, got %d", requests)
+	}
+
+	if req == nil || req.Method != http.MethodPost || !strings.HasPrefix(req.URL.String(), ts.URL+"/data/mutate/production") {
+		t.Fatalf("Unexpected request %+v", req)
+	}
+	if !strings.HasPrefix(req.Header.Get("User-Agent"), "go-sanity/") {
+		t.Errorf("Expected the User-Agent header, got '%s'", req.Header.Get("User-Agent"))
+	}
+	copied, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	body, _ := io.ReadAll(copied)
+	if !strings.Contains(string(body), `"delete":{"id":"a"}`) {
+		t.Errorf("Expected the mutations in the body, got %s", body)
+	}
+
+	if _, err := client.client.Do(req); err != nil {
+		t.Fatalf("Expected the request to be sent, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	var header http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	ctx := WithHeader(context.Background(), "X-Sanity-Project-ID", "test-project")
+	ctx = WithHeader(ctx, "x-debug", "1")
+	if _, err := client.Projects.Get(ctx, "test-project"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if header.Get("X-Sanity-Project-ID") != "test-project" || header.Get("X-Debug") != "1" {
+		t.Errorf("Expected the headers of the context, got %v", header)
+	}
+	if !strings.HasPrefix(header.Get("User-Agent"), "go-sanity/") {
+		t.Errorf("Expected the User-Agent header, got '%s'", header.Get("User-Agent"))
+	}
+
+	if _, err := client.Projects.Get(context.Background(), "test-project"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if header.Get("X-Debug") != "" {
+		t.Errorf("Expected no headers without the context, got %v", header)
+	}
+}
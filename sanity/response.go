@@ -0,0 +1,59 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// Response describes the HTTP response to a request made by the client.
+type Response struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header is the header of the response, e.g., for its `Cache-Control`
+	// directives.
+	Header http.Header
+
+	// RequestId is the identifier the API assigned to the request.
+	RequestId string
+}
+
+type responseKey struct{}
+
+// responseRecorder guards a Response against concurrent requests made with the
+// same context.
+type responseRecorder struct {
+	mu   sync.Mutex
+	resp *Response
+}
+
+// WithResponse returns a context that makes the client record the HTTP
+// response to requests made with the context into `resp`. It gives access to
+// the status code and header of responses without changing the signatures of
+// the functions of the client:
+//
+//	var resp sanity.Response
+//	doc, err := client.Documents.Get(sanity.WithResponse(ctx, &resp), projectId, dataset, id)
+//	log.Printf("request %s: %d", resp.RequestId, resp.StatusCode)
+//
+// If a call makes several requests, `resp` describes the last response.
+func WithResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, &responseRecorder{resp: resp})
+}
+
+// recordResponse records the response into the Response of the context, if
+// any.
+func recordResponse(ctx context.Context, resp *http.Response) {
+	r, ok := ctx.Value(responseKey{}).(*responseRecorder)
+	if !ok || r.resp == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resp.StatusCode = resp.StatusCode
+	r.resp.Header = resp.Header.Clone()
+	r.resp.RequestId = resp.Header.Get("X-Request-Id")
+}
@@ -0,0 +1,351 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchedulesService is a client for the Sanity Scheduling API, which publishes
+// and unpublishes documents at a given time.
+//
+// Refer to https://www.sanity.io/docs/scheduling-api for more information.
+type SchedulesService service
+
+const (
+	ScheduleActionPublish   = "publish"
+	ScheduleActionUnpublish = "unpublish"
+)
+
+const (
+	ScheduleStateScheduled = "scheduled"
+	ScheduleStateSucceeded = "succeeded"
+	ScheduleStateCancelled = "cancelled"
+)
+
+// A Schedule is an action that is applied to documents at a given time.
+type Schedule struct {
+	// Id is the unique identifier for the schedule.
+	Id string `json:"id"`
+
+	// Name is the human-readable name for the schedule.
+	Name string `json:"name"`
+
+	// ProjectId is the identifier of the project of the documents.
+	ProjectId string `json:"projectId"`
+
+	// Dataset is the dataset of the documents.
+	Dataset string `json:"dataset"`
+
+	// Author is the identifier of the user who created the schedule.
+	Author string `json:"author"`
+
+	// Action is the action applied to the documents. Valid values are
+	// represented as the `ScheduleAction*` constants in this package.
+	Action string `json:"action"`
+
+	// State is the state of the schedule. Valid values are represented as the
+	// `ScheduleState*` constants in this package.
+	State string `json:"state"`
+
+	// StateReason explains the state of the schedule, e.g., why it was
+	// cancelled.
+	StateReason string `json:"stateReason,omitempty"`
+
+	// Documents are the documents the action is applied to.
+	Documents []ScheduleDocument `json:"documents"`
+
+	// CreatedAt is the time the schedule was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExecuteAt is the time the action is applied.
+	ExecuteAt time.Time `json:"executeAt"`
+
+	// ExecutedAt is the time the action was applied, if it has been.
+	ExecutedAt *time.Time `json:"executedAt,omitempty"`
+}
+
+// A ScheduleDocument identifies a document of a schedule.
+type ScheduleDocument struct {
+	// DocumentId is the identifier of the published document.
+	DocumentId string `json:"documentId"`
+}
+
+// CreateScheduleRequest describes a schedule to create.
+type CreateScheduleRequest struct {
+	// Name is the human-readable name for the schedule.
+	Name string `json:"name,omitempty"`
+
+	// Action is the action to apply to the documents. Valid values are
+	// represented as the `ScheduleAction*` constants in this package. Defaults
+	// to ScheduleActionPublish.
+	Action string `json:"action,omitempty"`
+
+	// DocumentIds are the identifiers of the published documents. When
+	// publishing, the drafts of the documents are published.
+	DocumentIds []string `json:"-"`
+
+	// ExecuteAt is the time to apply the action.
+	ExecuteAt time.Time `json:"executeAt"`
+}
+
+// Create schedules an action on documents at a given time.
+func (s *SchedulesService) Create(ctx context.Context, projectId, dataset string, r *CreateScheduleRequest) (*Schedule, error) {
+	if len(r.DocumentIds) == 0 {
+		return nil, errors.New("at least one document id is required")
+	}
+	if r.ExecuteAt.IsZero() {
+		return nil, errors.New("execution time is required")
+	}
+
+	url := fmt.Sprintf("%s/schedules/%s/%s", s.client.projectBaseURL(projectId), projectId, dataset)
+
+	type request struct {
+		*CreateScheduleRequest
+		Documents []ScheduleDocument `json:"documents"`
+	}
+
+	req := &request{CreateScheduleRequest: r}
+	for _, id := range r.DocumentIds {
+		req.Documents = append(req.Documents, ScheduleDocument{DocumentId: id})
+	}
+
+	var schedule Schedule
+	err := do(ctx, s.client.client, url, http.MethodPost, req, &schedule)
+
+	return &schedule, err
+}
+
+// ListSchedulesRequest describes the schedules to list.
+type ListSchedulesRequest struct {
+	// State limits the results to schedules in the state. Valid values are
+	// represented as the `ScheduleState*` constants in this package.
+	State string
+
+	// DocumentIds limits the results to schedules of any of the documents.
+	DocumentIds []string
+}
+
+// List returns the schedules of the dataset that match the request. The
+// request may be nil to list all schedules.
+func (s *SchedulesService) List(ctx context.Context, projectId, dataset string, r *ListSchedulesRequest) ([]Schedule, error) {
+	params := url.Values{}
+	if r != nil {
+		if r.State != "" {
+			params.Set("state", r.State)
+		}
+		for _, id := range r.DocumentIds {
+			params.Add("documentIds", id)
+		}
+	}
+
+	url := fmt.Sprintf("%s/schedules/%s/%s", s.client.projectBaseURL(projectId), projectId, dataset)
+	if len(params) > 0 {
+		url += "?" + params.Encode()
+	}
+
+	type response struct {
+		Schedules []Schedule `json:"schedules"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &resp)
+
+	return resp.Schedules, err
+}
+
+// Iterate returns an iterator over the schedules of the dataset that match the
+// request. The request may be nil to iterate all schedules.
+func (s *SchedulesService) Iterate(projectId, dataset string, r *ListSchedulesRequest) *Iterator[Schedule] {
+	return singlePage(func(ctx context.Context) ([]Schedule, error) {
+		return s.List(ctx, projectId, dataset, r)
+	}).Items()
+}
+
+// UpdateScheduleRequest describes the changes to a schedule.
+type UpdateScheduleRequest struct {
+	// Name is the new name for the schedule. It is unchanged if empty.
+	Name string `json:"name,omitempty"`
+
+	// ExecuteAt is the new time to apply the action. It is unchanged if
+	// nil.
+	ExecuteAt *time.Time `json:"executeAt,omitempty"`
+}
+
+// Update changes the name or execution time of a schedule. Only schedules in
+// the ScheduleStateScheduled state can be updated.
+func (s *SchedulesService) Update(ctx context.Context, projectId, dataset, scheduleId string, r *UpdateScheduleRequest) error {
+	url := fmt.Sprintf("%s/schedules/%s/%s/%s", s.client.projectBaseURL(projectId), projectId, dataset, scheduleId)
+
+	return do(ctx, s.client.client, url, http.MethodPatch, r, nil)
+}
+
+// Delete removes a schedule. Deleting a schedule that has not been executed
+// cancels its action.
+func (s *SchedulesService) Delete(ctx context.Context, projectId, dataset, scheduleId string) error {
+	url := fmt.Sprintf("%s/schedules/%s/%s/%s", s.client.projectBaseURL(projectId), projectId, dataset, scheduleId)
+
+	return do(ctx, s.client.client, url, http.MethodDelete, nil, nil)
+}
+
+// Execute applies the action of a schedule immediately rather than at its
+// execution time.
+func (s *SchedulesService) Execute(ctx context.Context, projectId, dataset, scheduleId string) error {
+	return s.transition(ctx, projectId, dataset, scheduleId, "publish")
+}
+
+// Cancel stops a schedule from being executed. The schedule is kept in the
+// ScheduleStateCancelled state.
+func (s *SchedulesService) Cancel(ctx context.Context, projectId, dataset, scheduleId string) error {
+	return s.transition(ctx, projectId, dataset, scheduleId, "unschedule")
+}
+
+// transition moves a schedule to another state via the state-transition
+// endpoint.
+func (s *SchedulesService) transition(ctx context.Context, projectId, dataset, scheduleId, transition string) error {
+	url := fmt.Sprintf("%s/schedules/%s/%s/%s/%s", s.client.projectBaseURL(projectId), projectId, dataset, scheduleId, transition)
+
+	return do(ctx, s.client.client, url, http.MethodPost, nil, nil)
+}
+
+// Upcoming returns the pending schedules of a document, ordered by execution
+// time with the next one first. Drafts are scheduled by their published
+// identifier, so a `drafts.` prefix on the identifier is ignored.
+func (s *SchedulesService) Upcoming(ctx context.Context, projectId, dataset, documentId string) ([]Schedule, error) {
+	schedules, err := s.List(ctx, projectId, dataset, &ListSchedulesRequest{
+		State:       ScheduleStateScheduled,
+		DocumentIds: []string{strings.TrimPrefix(documentId, "drafts.")},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].ExecuteAt.Before(schedules[j].ExecuteAt)
+	})
+
+	return schedules, nil
+}
+
+const (
+	// ScheduleWarningDocumentNotFound is reported when neither a published
+	// document nor a draft exists for the document identifier.
+	ScheduleWarningDocumentNotFound = "documentNotFound"
+
+	// ScheduleWarningNoDraft is reported when a document scheduled to be
+	// published has no draft, so there are no changes to publish.
+	ScheduleWarningNoDraft = "noDraft"
+
+	// ScheduleWarningNotPublished is reported when a document scheduled to be
+	// unpublished is not published.
+	ScheduleWarningNotPublished = "notPublished"
+
+	// ScheduleWarningAlreadyScheduled is reported when a document is already
+	// part of another pending schedule.
+	ScheduleWarningAlreadyScheduled = "alreadyScheduled"
+
+	// ScheduleWarningExecuteAtInPast is reported when the execution time is
+	// not in the future.
+	ScheduleWarningExecuteAtInPast = "executeAtInPast"
+)
+
+// A ScheduleWarning describes a reason a schedule is unlikely to have the
+// intended effect when it is executed.
+type ScheduleWarning struct {
+	// DocumentId is the identifier of the document the warning applies to. It
+	// is empty for warnings about the schedule itself.
+	DocumentId string
+
+	// Code identifies the kind of warning. Valid values are represented as the
+	// `ScheduleWarning*` constants in this package.
+	Code string
+
+	// Message is a human-readable description of the warning.
+	Message string
+}
+
+// Preflight checks that the schedule described by the request can be executed
+// as intended, e.g., that the referenced documents exist and have changes to
+// publish. It returns a warning for each problem found, or none if the
+// schedule looks sound. Preflight does not create the schedule.
+func (s *SchedulesService) Preflight(ctx context.Context, projectId, dataset string, r *CreateScheduleRequest) ([]ScheduleWarning, error) {
+	if len(r.DocumentIds) == 0 {
+		return nil, errors.New("at least one document id is required")
+	}
+
+	var warnings []ScheduleWarning
+	if !r.ExecuteAt.After(time.Now()) {
+		warnings = append(warnings, ScheduleWarning{
+			Code:    ScheduleWarningExecuteAtInPast,
+			Message: "execution time is not in the future",
+		})
+	}
+
+	var ids []string
+	for _, id := range r.DocumentIds {
+		ids = append(ids, id, "drafts."+id)
+	}
+	docs, _, err := s.client.Documents.GetMany(ctx, projectId, dataset, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		found[doc.Id()] = true
+	}
+
+	pending, err := s.List(ctx, projectId, dataset, &ListSchedulesRequest{
+		State:       ScheduleStateScheduled,
+		DocumentIds: r.DocumentIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := map[string][]string{}
+	for _, schedule := range pending {
+		for _, doc := range schedule.Documents {
+			scheduled[doc.DocumentId] = append(scheduled[doc.DocumentId], schedule.Id)
+		}
+	}
+
+	for _, id := range r.DocumentIds {
+		published, draft := found[id], found["drafts."+id]
+		switch {
+		case !published && !draft:
+			warnings = append(warnings, ScheduleWarning{
+				DocumentId: id,
+				Code:       ScheduleWarningDocumentNotFound,
+				Message:    "document does not exist",
+			})
+		case r.Action == ScheduleActionUnpublish && !published:
+			warnings = append(warnings, ScheduleWarning{
+				DocumentId: id,
+				Code:       ScheduleWarningNotPublished,
+				Message:    "document is not published",
+			})
+		case r.Action != ScheduleActionUnpublish && !draft:
+			warnings = append(warnings, ScheduleWarning{
+				DocumentId: id,
+				Code:       ScheduleWarningNoDraft,
+				Message:    "document has no draft to publish",
+			})
+		}
+
+		if others := scheduled[id]; len(others) > 0 {
+			warnings = append(warnings, ScheduleWarning{
+				DocumentId: id,
+				Code:       ScheduleWarningAlreadyScheduled,
+				Message:    fmt.Sprintf("document is already scheduled by %s", strings.Join(others, ", ")),
+			})
+		}
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,247 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSchedulesService_Create(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/schedules/test-project/production" {
+			t.Errorf("Expected /schedules/test-project/production path, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		docs, _ := body["documents"].([]any)
+		if len(docs) != 1 || docs[0].(map[string]any)["documentId"] != "post_1" {
+			t.Errorf("Unexpected documents %v", body["documents"])
+		}
+		if body["executeAt"] != "2024-05-01T09:00:00Z" || body["action"] != ScheduleActionUnpublish {
+			t.Errorf("Unexpected body %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"sch-1","name":"Launch","action":"unpublish","state":"scheduled","documents":[{"documentId":"post_1"}],"executeAt":"2024-05-01T09:00:00Z"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	schedule, err := client.Schedules.Create(context.Background(), "test-project", "production", &CreateScheduleRequest{
+		Name:        "Launch",
+		Action:      ScheduleActionUnpublish,
+		DocumentIds: []string{"post_1"},
+		ExecuteAt:   time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if schedule.Id != "sch-1" || schedule.State != ScheduleStateScheduled || len(schedule.Documents) != 1 {
+		t.Errorf("Unexpected schedule %+v", schedule)
+	}
+}
+
+func TestSchedulesService_Create_MissingDocuments(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.Schedules.Create(context.Background(), "test-project", "production", &CreateScheduleRequest{ExecuteAt: time.Now()})
+	if err == nil {
+		t.Error("Expected an error for missing documents")
+	}
+}
+
+func TestSchedulesService_List(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/schedules/test-project/production" {
+			t.Errorf("Expected /schedules/test-project/production path, got %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("state") != ScheduleStateSucceeded {
+			t.Errorf("Expected state 'succeeded', got %s", r.URL.RawQuery)
+		}
+		if ids := query["documentIds"]; len(ids) != 2 || ids[0] != "post_1" || ids[1] != "post_2" {
+			t.Errorf("Unexpected document ids %v", ids)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"schedules":[{"id":"sch-1","state":"succeeded","executedAt":"2024-05-01T09:00:01Z"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	schedules, err := client.Schedules.List(context.Background(), "test-project", "production", &ListSchedulesRequest{
+		State:       ScheduleStateSucceeded,
+		DocumentIds: []string{"post_1", "post_2"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(schedules) != 1 || schedules[0].Id != "sch-1" || schedules[0].ExecutedAt == nil {
+		t.Errorf("Unexpected schedules %+v", schedules)
+	}
+}
+
+func TestSchedulesService_Update(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected PATCH method, got %s", r.Method)
+		}
+		if r.URL.Path != "/schedules/test-project/production/sch-1" {
+			t.Errorf("Expected /schedules/test-project/production/sch-1 path, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 || body["executeAt"] != "2024-06-01T09:00:00Z" {
+			t.Errorf("Unexpected body %v", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	executeAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	err := client.Schedules.Update(context.Background(), "test-project", "production", "sch-1", &UpdateScheduleRequest{ExecuteAt: &executeAt})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestSchedulesService_Delete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/schedules/test-project/production/sch-1" {
+			t.Errorf("Expected /schedules/test-project/production/sch-1 path, got %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	if err := client.Schedules.Delete(context.Background(), "test-project", "production", "sch-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestSchedulesService_ExecuteAndCancel(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		paths = append(paths, r.URL.Path)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	if err := client.Schedules.Execute(context.Background(), "test-project", "production", "sch-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.Schedules.Cancel(context.Background(), "test-project", "production", "sch-2"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"/schedules/test-project/production/sch-1/publish", "/schedules/test-project/production/sch-2/unschedule"}
+	if len(paths) != 2 || paths[0] != expected[0] || paths[1] != expected[1] {
+		t.Errorf("Expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestSchedulesService_Preflight(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/data/doc/production/post_1,drafts.post_1,post_2,drafts.post_2,post_3,drafts.post_3":
+			w.Write([]byte(`{"documents":[{"_id":"drafts.post_1"},{"_id":"post_2"}]}`))
+		case "/schedules/test-project/production":
+			if r.URL.Query().Get("state") != ScheduleStateScheduled {
+				t.Errorf("Expected only pending schedules to be listed, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"schedules":[{"id":"sch-9","documents":[{"documentId":"post_1"}]}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	warnings, err := client.Schedules.Preflight(context.Background(), "test-project", "production", &CreateScheduleRequest{
+		DocumentIds: []string{"post_1", "post_2", "post_3"},
+		ExecuteAt:   time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []ScheduleWarning{
+		{DocumentId: "post_1", Code: ScheduleWarningAlreadyScheduled},
+		{DocumentId: "post_2", Code: ScheduleWarningNoDraft},
+		{DocumentId: "post_3", Code: ScheduleWarningDocumentNotFound},
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %+v", len(expected), warnings)
+	}
+	for i, w := range warnings {
+		if w.DocumentId != expected[i].DocumentId || w.Code != expected[i].Code {
+			t.Errorf("Warning %d: expected %s for %s, got %+v", i, expected[i].Code, expected[i].DocumentId, w)
+		}
+	}
+}
+
+func TestSchedulesService_Upcoming(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != ScheduleStateScheduled || query.Get("documentIds") != "post_1" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"schedules":[{"id":"later","action":"unpublish","executeAt":"2024-07-01T00:00:00Z"},{"id":"sooner","action":"publish","executeAt":"2024-06-01T00:00:00Z"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	schedules, err := client.Schedules.Upcoming(context.Background(), "test-project", "production", "drafts.post_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(schedules) != 2 || schedules[0].Id != "sooner" || schedules[1].Id != "later" {
+		t.Errorf("Expected schedules ordered by execution time, got %+v", schedules)
+	}
+}
@@ -0,0 +1,172 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SchemasService is a client for the schema store, which keeps the schemas of
+// studio workspaces as system documents in a dataset. Deployed schemas are
+// used by features such as Agent Actions, and can be fetched for validation
+// and code generation.
+//
+// Refer to https://www.sanity.io/docs/schema-deployment for more information.
+type SchemasService service
+
+// schemaDocumentVersion is the version of the format of schema documents.
+const schemaDocumentVersion = "2025-05-01"
+
+// SchemaId returns the identifier of the schema document of a workspace, e.g.,
+// `_.schemas.default`. The tag is optional, and distinguishes several schemas
+// deployed for the same workspace.
+func SchemaId(workspace, tag string) string {
+	id := "_.schemas." + workspace
+	if tag != "" {
+		id += "." + tag
+	}
+
+	return id
+}
+
+// A WorkspaceSchema is the deployed schema of a studio workspace.
+type WorkspaceSchema struct {
+	// Id is the identifier of the schema document.
+	Id string
+
+	// Workspace is the name of the workspace.
+	Workspace string
+
+	// WorkspaceTitle is the display title of the workspace.
+	WorkspaceTitle string
+
+	// Tag distinguishes several schemas deployed for the same workspace.
+	Tag string
+
+	// Schema is the schema manifest, as produced by `sanity schema extract`.
+	Schema json.RawMessage
+
+	// CreatedAt is the time the schema was first deployed.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time the schema was last deployed.
+	UpdatedAt time.Time
+}
+
+// schemaDocument is the stored form of a WorkspaceSchema.
+type schemaDocument struct {
+	Id        string `json:"_id"`
+	Type      string `json:"_type"`
+	Version   string `json:"version"`
+	Tag       string `json:"tag,omitempty"`
+	Workspace struct {
+		Name  string `json:"name"`
+		Title string `json:"title,omitempty"`
+	} `json:"workspace"`
+	// Schema is the schema manifest encoded as a JSON string.
+	Schema    string     `json:"schema"`
+	CreatedAt *time.Time `json:"_createdAt,omitempty"`
+	UpdatedAt *time.Time `json:"_updatedAt,omitempty"`
+}
+
+func (d *schemaDocument) workspaceSchema() *WorkspaceSchema {
+	schema := &WorkspaceSchema{
+		Id:             d.Id,
+		Workspace:      d.Workspace.Name,
+		WorkspaceTitle: d.Workspace.Title,
+		Tag:            d.Tag,
+		Schema:         json.RawMessage(d.Schema),
+	}
+	if d.CreatedAt != nil {
+		schema.CreatedAt = *d.CreatedAt
+	}
+	if d.UpdatedAt != nil {
+		schema.UpdatedAt = *d.UpdatedAt
+	}
+
+	return schema
+}
+
+// DeploySchemaRequest describes a workspace schema to deploy.
+type DeploySchemaRequest struct {
+	// Workspace is the name of the workspace. Defaults to `default`.
+	Workspace string
+
+	// WorkspaceTitle is the display title of the workspace.
+	WorkspaceTitle string
+
+	// Tag distinguishes several schemas deployed for the same workspace.
+	Tag string
+
+	// Schema is the schema manifest, as produced by `sanity schema extract`.
+	Schema json.RawMessage
+}
+
+// Deploy stores the schema of a workspace in the dataset, replacing any schema
+// previously deployed for the workspace and tag. It returns the identifier of
+// the schema document.
+func (s *SchemasService) Deploy(ctx context.Context, projectId, dataset string, r *DeploySchemaRequest) (string, error) {
+	if !json.Valid(r.Schema) {
+		return "", errors.New("schema must be valid JSON")
+	}
+
+	workspace := r.Workspace
+	if workspace == "" {
+		workspace = "default"
+	}
+
+	doc := &schemaDocument{
+		Id:      SchemaId(workspace, r.Tag),
+		Type:    "system.schema",
+		Version: schemaDocumentVersion,
+		Tag:     r.Tag,
+		Schema:  string(r.Schema),
+	}
+	doc.Workspace.Name = workspace
+	doc.Workspace.Title = r.WorkspaceTitle
+
+	_, err := s.client.Documents.Mutate(ctx, projectId, dataset, &MutateRequest{
+		Mutations: []Mutation{{CreateOrReplace: doc}},
+		ReturnIds: NewBool(false),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return doc.Id, nil
+}
+
+// Get fetches the deployed schema of a workspace. The tag is optional. A nil
+// WorkspaceSchema is returned if no schema is deployed for the workspace and
+// tag.
+func (s *SchemasService) Get(ctx context.Context, projectId, dataset, workspace, tag string) (*WorkspaceSchema, error) {
+	var doc *schemaDocument
+	err := s.client.Documents.Query(ctx, projectId, dataset, &QueryRequest{
+		Query:  `*[_type == "system.schema" && _id == $id][0]`,
+		Params: map[string]any{"id": SchemaId(workspace, tag)},
+	}, &doc)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	return doc.workspaceSchema(), nil
+}
+
+// List returns the deployed schemas of all workspaces in the dataset.
+func (s *SchemasService) List(ctx context.Context, projectId, dataset string) ([]WorkspaceSchema, error) {
+	var docs []schemaDocument
+	err := s.client.Documents.Query(ctx, projectId, dataset, &QueryRequest{
+		Query: `*[_type == "system.schema"] | order(_id asc)`,
+	}, &docs)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]WorkspaceSchema, 0, len(docs))
+	for i := range docs {
+		schemas = append(schemas, *docs[i].workspaceSchema())
+	}
+
+	return schemas, nil
+}
@@ -0,0 +1,93 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSchemaId(t *testing.T) {
+	if id := SchemaId("default", ""); id != "_.schemas.default" {
+		t.Errorf("Expected '_.schemas.default', got '%s'", id)
+	}
+	if id := SchemaId("blog", "staging"); id != "_.schemas.blog.staging" {
+		t.Errorf("Expected '_.schemas.blog.staging', got '%s'", id)
+	}
+}
+
+func TestSchemasService_Deploy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/mutate/production" {
+			t.Errorf("Expected /data/mutate/production path, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			Mutations []struct {
+				CreateOrReplace map[string]any `json:"createOrReplace"`
+			} `json:"mutations"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		doc := body.Mutations[0].CreateOrReplace
+		if doc["_id"] != "_.schemas.default" || doc["_type"] != "system.schema" || doc["schema"] != `[{"name":"post","type":"document"}]` {
+			t.Errorf("Unexpected document %v", doc)
+		}
+		if _, ok := doc["_createdAt"]; ok {
+			t.Errorf("Expected no _createdAt attribute, got %v", doc)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	id, err := client.Schemas.Deploy(context.Background(), "test-project", "production", &DeploySchemaRequest{
+		Schema: json.RawMessage(`[{"name":"post","type":"document"}]`),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if id != "_.schemas.default" {
+		t.Errorf("Expected ID '_.schemas.default', got '%s'", id)
+	}
+}
+
+func TestSchemasService_Deploy_InvalidSchema(t *testing.T) {
+	client := NewClient(nil)
+
+	_, err := client.Schemas.Deploy(context.Background(), "test-project", "production", &DeploySchemaRequest{Schema: json.RawMessage(`{`)})
+	if err == nil {
+		t.Error("Expected an error for an invalid schema")
+	}
+}
+
+func TestSchemasService_Get(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Params["id"] != "_.schemas.blog" {
+			t.Errorf("Unexpected params %v", req.Params)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":{"_id":"_.schemas.blog","_type":"system.schema","workspace":{"name":"blog","title":"Blog"},"schema":"[{\"name\":\"post\"}]","_updatedAt":"2024-01-01T00:00:00Z"}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	schema, err := client.Schemas.Get(context.Background(), "test-project", "production", "blog", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if schema == nil || schema.Workspace != "blog" || schema.WorkspaceTitle != "Blog" || string(schema.Schema) != `[{"name":"post"}]` || schema.UpdatedAt.IsZero() {
+		t.Errorf("Unexpected schema %+v", schema)
+	}
+}
@@ -0,0 +1,116 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// TasksService is a client for the tasks that editors assign to each other in
+// the studio.
+//
+// Like comments, tasks are stored as documents of type `tasks.task` in the
+// comments addon dataset of a dataset. The functions of TasksService accept the
+// name of the content dataset.
+type TasksService service
+
+const (
+	TaskStatusOpen   = "open"
+	TaskStatusClosed = "closed"
+)
+
+// A Task is a piece of work assigned to a user.
+type Task struct {
+	// Id is the unique identifier for the task.
+	Id string `json:"_id"`
+
+	// Title is the title of the task.
+	Title string `json:"title"`
+
+	// Description is the description of the task as Portable Text.
+	Description json.RawMessage `json:"description,omitempty"`
+
+	// Status is the status of the task. Valid values are represented as the
+	// `TaskStatus*` constants in this package.
+	Status string `json:"status"`
+
+	// AuthorId is the identifier of the user who created the task.
+	AuthorId string `json:"authorId"`
+
+	// AssignedTo is the identifier of the user the task is assigned to. It is
+	// empty if the task is unassigned.
+	AssignedTo string `json:"assignedTo,omitempty"`
+
+	// DueBy is the date the task is due, if any.
+	DueBy string `json:"dueBy,omitempty"`
+
+	// Target identifies the document the task is about, if any.
+	Target *TaskTarget `json:"target,omitempty"`
+
+	// CreatedAt is the time the task was created.
+	CreatedAt time.Time `json:"_createdAt"`
+
+	// UpdatedAt is the time the task was last updated.
+	UpdatedAt time.Time `json:"_updatedAt"`
+}
+
+// TaskTarget identifies the document a task is about.
+type TaskTarget struct {
+	// Document is a reference to the published document.
+	Document struct {
+		Ref string `json:"_ref"`
+	} `json:"document"`
+
+	// DocumentType is the type of the document.
+	DocumentType string `json:"documentType,omitempty"`
+}
+
+// Get fetches a single task. A nil Task is returned if the task does not
+// exist.
+func (s *TasksService) Get(ctx context.Context, projectId, dataset, taskId string) (*Task, error) {
+	var task *Task
+	err := s.client.Documents.Query(ctx, projectId, commentsDataset(dataset), &QueryRequest{
+		Query:  `*[_type == "tasks.task" && _id == $id][0]`,
+		Params: map[string]any{"id": taskId},
+	}, &task)
+
+	return task, err
+}
+
+// Assign assigns the task to a user. An empty user identifier unassigns the
+// task.
+func (s *TasksService) Assign(ctx context.Context, projectId, dataset, taskId, userId string) error {
+	patch := &Patch{Id: taskId, Set: map[string]any{"assignedTo": userId}}
+	if userId == "" {
+		patch = &Patch{Id: taskId, Unset: []string{"assignedTo"}}
+	}
+
+	return s.patch(ctx, projectId, dataset, patch)
+}
+
+// Close marks the task as done.
+func (s *TasksService) Close(ctx context.Context, projectId, dataset, taskId string) error {
+	return s.SetStatus(ctx, projectId, dataset, taskId, TaskStatusClosed)
+}
+
+// Reopen marks a closed task as open.
+func (s *TasksService) Reopen(ctx context.Context, projectId, dataset, taskId string) error {
+	return s.SetStatus(ctx, projectId, dataset, taskId, TaskStatusOpen)
+}
+
+// SetStatus moves the task to the status. Valid values are represented as the
+// `TaskStatus*` constants in this package.
+func (s *TasksService) SetStatus(ctx context.Context, projectId, dataset, taskId, status string) error {
+	return s.patch(ctx, projectId, dataset, &Patch{Id: taskId, Set: map[string]any{"status": status}})
+}
+
+// patch applies the patch to a task in the comments addon dataset of the
+// dataset.
+func (s *TasksService) patch(ctx context.Context, projectId, dataset string, patch *Patch) error {
+	_, err := s.client.Documents.Mutate(ctx, projectId, commentsDataset(dataset), &MutateRequest{
+		Mutations: []Mutation{{Patch: patch}},
+		ReturnIds: NewBool(false),
+	})
+
+	return err
+}
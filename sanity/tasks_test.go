@@ -0,0 +1,83 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTasksService_Get(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/query/production-comments" {
+			t.Errorf("Expected /data/query/production-comments path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":{"_id":"task-1","title":"Proofread","status":"open","assignedTo":"user-1","target":{"document":{"_ref":"post_1"},"documentType":"post"}}}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	task, err := client.Tasks.Get(context.Background(), "test-project", "production", "task-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if task == nil || task.Title != "Proofread" || task.AssignedTo != "user-1" || task.Target.Document.Ref != "post_1" {
+		t.Errorf("Unexpected task %+v", task)
+	}
+}
+
+func TestTasksService_AssignAndClose(t *testing.T) {
+	var patches []Patch
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/mutate/production-comments" {
+			t.Errorf("Expected /data/mutate/production-comments path, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			Mutations []struct {
+				Patch Patch `json:"patch"`
+			} `json:"mutations"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		for _, m := range body.Mutations {
+			patches = append(patches, m.Patch)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactionId":"tx-1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	ctx := context.Background()
+	if err := client.Tasks.Assign(ctx, "test-project", "production", "task-1", "user-2"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.Tasks.Assign(ctx, "test-project", "production", "task-1", ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.Tasks.Close(ctx, "test-project", "production", "task-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(patches) != 3 {
+		t.Fatalf("Expected 3 patches, got %d", len(patches))
+	}
+	if patches[0].Id != "task-1" || patches[0].Set["assignedTo"] != "user-2" {
+		t.Errorf("Unexpected assign patch %+v", patches[0])
+	}
+	if len(patches[1].Unset) != 1 || patches[1].Unset[0] != "assignedTo" {
+		t.Errorf("Unexpected unassign patch %+v", patches[1])
+	}
+	if patches[2].Set["status"] != TaskStatusClosed {
+		t.Errorf("Unexpected close patch %+v", patches[2])
+	}
+}
@@ -0,0 +1,111 @@
+package sanity
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithThrottle smooths the rate of requests of the client with a token bucket
+// that allows `rate` requests per second on average, in bursts of up to `burst`
+// requests. Requests beyond the rate wait for their turn, or until their
+// context is done.
+//
+// When the API rejects a request for exceeding its rate limit, the throttle
+// holds back all requests until the time given by the `Retry-After` header of
+// the response, so that requests queue up instead of being rejected in turn.
+// Retries, enabled with WithRetries, are throttled like other requests.
+func WithThrottle(rate float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.throttle = nil
+		if rate > 0 {
+			c.throttle = newThrottle(rate, burst, time.Now())
+		}
+	}
+}
+
+// throttle is a token bucket that requests take a token from before they are
+// sent. The bucket may go into debt, in which case requests wait until the
+// debt is repaid.
+type throttle struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	// last is the time the tokens were last refilled. It is in the future
+	// while the throttle is paused.
+	last time.Time
+}
+
+func newThrottle(rate float64, burst int, now time.Time) *throttle {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &throttle{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// wait takes a token from the bucket, waiting until it is available or the
+// context is done.
+func (t *throttle) wait(ctx context.Context) error {
+	delay := t.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		t.release()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token from the bucket and returns the time to wait until it
+// is available.
+func (t *throttle) reserve(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.After(t.last) {
+		t.tokens += now.Sub(t.last).Seconds() * t.rate
+		if t.tokens > t.burst {
+			t.tokens = t.burst
+		}
+		t.last = now
+	}
+
+	t.tokens--
+	delay := t.last.Sub(now)
+	if t.tokens < 0 {
+		delay += time.Duration(-t.tokens / t.rate * float64(time.Second))
+	}
+
+	return delay
+}
+
+// release returns a token that was reserved but not used.
+func (t *throttle) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tokens++
+}
+
+// pause stops the bucket from refilling until the time given, and empties it,
+// so that no requests are sent before then.
+func (t *throttle) pause(until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if until.After(t.last) {
+		t.last = until
+	}
+	if t.tokens > 0 {
+		t.tokens = 0
+	}
+}
@@ -0,0 +1,76 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestThrottle_Reserve(t *testing.T) {
+	now := time.Now()
+	throttle := newThrottle(10, 2, now)
+
+	for i, expected := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if delay := throttle.reserve(now); delay != expected {
+			t.Errorf("%d: Expected a delay of %v, got %v", i, expected, delay)
+		}
+	}
+
+	// The debt of two tokens is repaid after 200ms, and the bucket refills
+	// up to the burst.
+	if delay := throttle.reserve(now.Add(time.Second)); delay != 0 {
+		t.Errorf("Expected no delay, got %v", delay)
+	}
+}
+
+func TestThrottle_Pause(t *testing.T) {
+	now := time.Now()
+	throttle := newThrottle(10, 5, now)
+	throttle.pause(now.Add(2 * time.Second))
+
+	if delay := throttle.reserve(now); delay != 2*time.Second+100*time.Millisecond {
+		t.Errorf("Expected a delay of 2.1s, got %v", delay)
+	}
+}
+
+func TestClient_Throttle(t *testing.T) {
+	var times []time.Time
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		if len(times) == 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithThrottle(100, 1))
+	client.baseURL = ts.URL
+
+	for i := 0; i < 3; i++ {
+		client.Projects.Get(context.Background(), "test-project")
+	}
+
+	if len(times) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(times))
+	}
+	if gap := times[2].Sub(times[1]); gap < time.Second {
+		t.Errorf("Expected the requests to be held back after a 429, got a gap of %v", gap)
+	}
+}
+
+func TestClient_ThrottleCanceled(t *testing.T) {
+	client := NewClient(http.DefaultClient, WithThrottle(1, 1))
+	client.throttle.pause(time.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Projects.Get(ctx, "test-project"); err == nil {
+		t.Error("Expected an error")
+	}
+}
@@ -0,0 +1,155 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// A TracerProvider starts spans for the requests made by the client.
+//
+// The package does not depend on OpenTelemetry, but a TracerProvider is easily
+// implemented with it:
+//
+//	type otelTracer struct{ tracer trace.Tracer }
+//
+//	func (t otelTracer) StartSpan(ctx context.Context, name string, attrs map[string]any) (context.Context, sanity.Span) {
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		s := otelSpan{span}
+//		s.SetAttributes(attrs)
+//		return ctx, s
+//	}
+//
+//	type otelSpan struct{ span trace.Span }
+//
+//	func (s otelSpan) SetAttributes(attrs map[string]any) {
+//		for k, v := range attrs {
+//			s.span.SetAttributes(attribute.String(k, fmt.Sprint(v)))
+//		}
+//	}
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.span.RecordError(err)
+//		s.span.SetStatus(codes.Error, err.Error())
+//	}
+//
+//	func (s otelSpan) End() { s.span.End() }
+//
+//	client := sanity.NewClient(httpClient, sanity.WithTracerProvider(otelTracer{otel.Tracer("sanity")}))
+type TracerProvider interface {
+	// StartSpan starts a span with the name and attributes, and returns a
+	// context that carries the span.
+	StartSpan(ctx context.Context, name string, attrs map[string]any) (context.Context, Span)
+}
+
+// A Span traces a single call to the Sanity API.
+type Span interface {
+	// SetAttributes adds the attributes to the span.
+	SetAttributes(attrs map[string]any)
+
+	// RecordError marks the span as failed with the error.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// WithTracerProvider makes the client start a span for each call to the Sanity
+// API, named after the service and operation of the call, e.g.,
+// `sanity.data.query`. Spans have the following attributes:
+//
+//   - `sanity.service`: the API that is called, e.g., `data` or `projects`
+//   - `sanity.operation`: the operation of the API, e.g., `query` or `GET`
+//   - `sanity.project_id`: the project, if the call is scoped to one
+//   - `http.method`: the HTTP method of the request
+//   - `http.status_code`: the HTTP status code of the response
+//
+// Retries of a request that is rejected by rate limiting are part of the same
+// span.
+func WithTracerProvider(tp TracerProvider) ClientOption {
+	return func(c *Client) {
+		c.tracerProvider = tp
+	}
+}
+
+// requestInfo describes the Sanity API call of a request.
+type requestInfo struct {
+	service   string
+	operation string
+	projectId string
+}
+
+// apiVersionPattern matches the API version segment of a path, e.g.,
+// `v2021-06-07`, `v1`, or `vX`.
+var apiVersionPattern = regexp.MustCompile(`^v(\d+|\d{4}-\d{2}-\d{2}|X)$`)
+
+// describeRequest identifies the Sanity API call of the request from its URL.
+func (c *Client) describeRequest(req *http.Request) requestInfo {
+	var info requestInfo
+
+	path := req.URL.Path
+	if projectId, p, ok := c.projectHost.parse(req.URL); ok {
+		info.projectId, path = projectId, p
+	} else if base, err := url.Parse(c.baseURL); err == nil && req.URL.Host == base.Host {
+		path = strings.TrimPrefix(path, base.Path)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) > 0 && apiVersionPattern.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return info
+	}
+
+	info.service = segments[0]
+	info.operation = req.Method
+	switch info.service {
+	case "data", "agent":
+		// The data APIs are distinguished by their second segment, e.g.,
+		// `/data/query/<dataset>`, as are agent actions, e.g.,
+		// `/agent/action/generate/<dataset>`.
+		for _, segment := range segments[1:] {
+			if segment != "action" {
+				info.operation = segment
+				break
+			}
+		}
+	case "hooks":
+		info.service = "webhooks"
+	}
+
+	for i, segment := range segments[:len(segments)-1] {
+		if info.projectId == "" && (segment == "projects" || segment == "project") {
+			info.projectId = segments[i+1]
+		}
+	}
+
+	return info
+}
+
+// attributes returns the span attributes describing the call.
+func (i requestInfo) attributes(method string) map[string]any {
+	attrs := map[string]any{
+		"sanity.service":   i.service,
+		"sanity.operation": i.operation,
+		"http.method":      method,
+	}
+	if i.projectId != "" {
+		attrs["sanity.project_id"] = i.projectId
+	}
+
+	return attrs
+}
+
+// spanName returns the name of the span of the call.
+func (i requestInfo) spanName() string {
+	return "sanity." + i.service + "." + i.operation
+}
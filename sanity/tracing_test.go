@@ -0,0 +1,94 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) StartSpan(ctx context.Context, name string, attrs map[string]any) (context.Context, Span) {
+	span := &testSpan{name: name, attrs: attrs}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+type testSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs map[string]any) {
+	for k, v := range attrs {
+		s.attrs[k] = v
+	}
+}
+
+func (s *testSpan) RecordError(err error) { s.err = err }
+
+func (s *testSpan) End() { s.ended = true }
+
+func TestWithTracerProvider(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2021-06-07/projects/test-project" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer ts.Close()
+
+	tracer := &testTracer{}
+	client := NewClient(http.DefaultClient, WithTracerProvider(tracer))
+	client.baseURL = ts.URL
+	client.testProjectBaseURL = ts.URL
+
+	var docs []Document
+	client.Documents.Query(context.Background(), "test-project", "production", &QueryRequest{Query: "*"}, &docs)
+	client.Projects.Get(context.Background(), "test-project")
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(tracer.spans))
+	}
+
+	query := tracer.spans[0]
+	if query.name != "sanity.data.query" || query.attrs["http.status_code"] != http.StatusOK || query.err != nil || !query.ended {
+		t.Errorf("Unexpected query span %+v", query)
+	}
+
+	get := tracer.spans[1]
+	if get.name != "sanity.projects.GET" || get.attrs["sanity.project_id"] != "test-project" || get.err == nil || !get.ended {
+		t.Errorf("Unexpected project span %+v", get)
+	}
+}
+
+func TestDescribeRequest(t *testing.T) {
+	tests := []struct {
+		method    string
+		url       string
+		service   string
+		operation string
+		projectId string
+	}{
+		{"POST", "https://abc123.api.sanity.io/v2025-02-19/data/mutate/production", "data", "mutate", "abc123"},
+		{"GET", "https://api.sanity.io/v2021-06-07/projects/abc123/datasets", "projects", "GET", "abc123"},
+		{"DELETE", "https://api.sanity.io/v2021-10-04/hooks/projects/abc123/hook-1", "webhooks", "DELETE", "abc123"},
+		{"POST", "https://abc123.api.sanity.io/v2025-02-19/agent/action/generate/production", "agent", "generate", "abc123"},
+		{"GET", "https://api.sanity.io/v2021-06-07/users/me", "users", "GET", ""},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, tt.url, nil)
+		info := NewClient(nil).describeRequest(req)
+		if info.service != tt.service || info.operation != tt.operation || info.projectId != tt.projectId {
+			t.Errorf("%s %s: expected %s/%s/%s, got %+v", tt.method, tt.url, tt.service, tt.operation, tt.projectId, info)
+		}
+	}
+}
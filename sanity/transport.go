@@ -0,0 +1,384 @@
+package sanity
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit describes the rate limit of the Sanity API as reported by the most
+// recent response.
+type RateLimit struct {
+	// Limit is the maximum number of requests in the current window. It is
+	// zero if no response has reported a rate limit.
+	Limit int
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+
+	// Reset is the time the rate limit is lifted after a request was rejected
+	// for exceeding it. It is zero if no request was rejected.
+	Reset time.Time
+}
+
+// rateLimitState holds the most recently reported rate limit of a client.
+type rateLimitState struct {
+	mu        sync.Mutex
+	rateLimit RateLimit
+}
+
+func (s *rateLimitState) get() RateLimit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rateLimit
+}
+
+// update records the rate limit reported by the response, if any.
+func (s *rateLimitState) update(resp *http.Response, now time.Time) {
+	limit, hasLimit := headerInt(resp.Header, "X-RateLimit-Limit-Second", "X-RateLimit-Limit")
+	remaining, hasRemaining := headerInt(resp.Header, "X-RateLimit-Remaining-Second", "X-RateLimit-Remaining")
+	delay, hasDelay := retryAfter(resp.Header.Get("Retry-After"), now)
+	if !hasLimit && !hasRemaining && !hasDelay {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hasLimit {
+		s.rateLimit.Limit = limit
+	}
+	if hasRemaining {
+		s.rateLimit.Remaining = remaining
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		s.rateLimit.Remaining = 0
+		if hasDelay {
+			s.rateLimit.Reset = now.Add(delay)
+		}
+	}
+}
+
+// headerInt returns the integer value of the first of the headers that is
+// present.
+func headerInt(h http.Header, keys ...string) (int, bool) {
+	for _, key := range keys {
+		if v := h.Get(key); v != "" {
+			n, err := strconv.Atoi(v)
+			return n, err == nil
+		}
+	}
+
+	return 0, false
+}
+
+// retryAfter parses the value of a `Retry-After` header, which is either a
+// number of seconds or an HTTP date, into the time to wait from `now`.
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if t.Before(now) {
+			return 0, true
+		}
+		return t.Sub(now), true
+	}
+
+	return 0, false
+}
+
+// TransportOptions tunes the connection pool and protocol of the HTTP transport
+// of a client. Zero values keep the defaults of http.DefaultTransport.
+type TransportOptions struct {
+	// MaxIdleConns is the maximum number of idle connections across all
+	// hosts.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per
+	// host. The default of 2 is too low for highly parallel jobs, such as
+	// imports, which otherwise open and close connections continuously.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the total number of connections per host,
+	// including connections in use.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is the time an idle connection is kept open.
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 makes the client use HTTP/1.1 only.
+	DisableHTTP2 bool
+}
+
+// WithTransportOptions tunes the HTTP transport the client sends requests
+// with. The options apply if the transport of the `http.Client` passed to
+// NewClient is nil, i.e., http.DefaultTransport, or an *http.Transport, which
+// is copied rather than modified. They have no effect on other transports, such
+// as one that adds authentication, which should be tuned directly.
+func WithTransportOptions(opts TransportOptions) ClientOption {
+	return func(c *Client) {
+		c.transportOptions = &opts
+	}
+}
+
+// tune returns a copy of the transport with the options applied, or the
+// transport unchanged if it cannot be tuned.
+func (o *TransportOptions) tune(rt http.RoundTripper) http.RoundTripper {
+	base, ok := rt.(*http.Transport)
+	if o == nil || !ok {
+		return rt
+	}
+
+	t := base.Clone()
+	if o.MaxIdleConns > 0 {
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = o.MaxConnsPerHost
+	}
+	if o.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = o.IdleConnTimeout
+	}
+	if o.DisableHTTP2 {
+		// A non-nil, empty map of protocol upgrades disables HTTP/2.
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return t
+}
+
+// transport is the round tripper of the HTTP client of a Client. It records the
+// rate limit reported by responses, retries requests that are rejected for
+// exceeding it, sets the user agent and the headers of the context, applies the
+// default timeout, limits the requests in flight, throttles the rate of
+// requests, revalidates cached responses, and records the final response for
+// WithResponse.
+type transport struct {
+	base   http.RoundTripper
+	client *Client
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = addHeaders(req)
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.client.userAgent)
+	}
+	if captureRequest(req) {
+		return nil, ErrNotSent
+	}
+
+	// Event streams are long-lived by design, so they are exempt from the
+	// default timeout.
+	if t.client.timeout <= 0 || isEventStream(req) {
+		return t.limit(req)
+	}
+	if _, ok := req.Context().Deadline(); ok {
+		return t.limit(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.client.timeout)
+	resp, err := t.limit(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The deadline also applies to reading the body, so the context is
+	// released when the body is closed.
+	resp.Body = &closeHookBody{ReadCloser: resp.Body, hook: cancel}
+
+	return resp, nil
+}
+
+type streamedKey struct{}
+
+// isStreamed reports whether the response to the request is returned to the
+// caller as a stream, see openStream.
+func isStreamed(req *http.Request) bool {
+	streamed, _ := req.Context().Value(streamedKey{}).(bool)
+	return streamed
+}
+
+// isEventStream reports whether the request opens a stream of Server-Sent
+// Events, such as the Listen API.
+func isEventStream(req *http.Request) bool {
+	return req.Header.Get("Accept") == "text/event-stream"
+}
+
+// limit sends the request once fewer than the maximum number of requests are
+// in flight. A request is in flight until the body of its response is closed,
+// except that streamed responses, which are read while the caller makes
+// further requests, release their slot once the response headers arrive.
+// Event streams are not limited, as they would hold their slot indefinitely.
+func (t *transport) limit(req *http.Request) (*http.Response, error) {
+	slots := t.client.slots
+	if slots == nil || isEventStream(req) {
+		return t.observe(req)
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := func() { <-slots }
+
+	resp, err := t.observe(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if isStreamed(req) {
+		release()
+		return resp, nil
+	}
+	resp.Body = &closeHookBody{ReadCloser: resp.Body, hook: release}
+
+	return resp, nil
+}
+
+// closeHookBody is a response body that calls a hook the first time it is
+// closed, e.g., to cancel the context of its request.
+type closeHookBody struct {
+	io.ReadCloser
+	hook func()
+	once sync.Once
+}
+
+func (b *closeHookBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.hook)
+
+	return err
+}
+
+// observe sends the request, tracing it and recording metrics for it if the
+// client is configured to do so.
+func (t *transport) observe(req *http.Request) (*http.Response, error) {
+	if t.client.tracerProvider == nil && t.client.metrics == nil {
+		return t.cachedRoundTrip(req)
+	}
+
+	info := t.client.describeRequest(req)
+	var span Span
+	if t.client.tracerProvider != nil {
+		var ctx context.Context
+		ctx, span = t.client.tracerProvider.StartSpan(req.Context(), info.spanName(), info.attributes(req.Method))
+		defer span.End()
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+	resp, err := t.cachedRoundTrip(req)
+	if err == nil && resp.StatusCode > 299 {
+		err = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	if t.client.metrics != nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		t.client.metrics.ObserveRequest(info.service, info.operation, statusCode, time.Since(start), err)
+	}
+	if span != nil {
+		if resp != nil {
+			span.SetAttributes(map[string]any{"http.status_code": resp.StatusCode})
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+	}
+
+	if resp == nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// roundTrip sends the request, retrying it while it is rejected by rate
+// limiting and retries are enabled.
+func (t *transport) roundTrip(req *http.Request) (*http.Response, error) {
+	throttle := t.client.throttle
+	for attempt := 0; ; attempt++ {
+		if throttle != nil {
+			if err := throttle.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		t.client.rateLimit.update(resp, now)
+
+		delay, ok := retryAfter(resp.Header.Get("Retry-After"), now)
+		if !ok {
+			delay = retryBackoff(attempt)
+		}
+		if throttle != nil && resp.StatusCode == http.StatusTooManyRequests {
+			throttle.pause(now.Add(delay))
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= t.client.maxRetries {
+			recordResponse(req.Context(), resp)
+			return resp, nil
+		}
+		// Requests with a streamed body cannot be sent again.
+		if req.Body != nil && req.GetBody == nil {
+			recordResponse(req.Context(), resp)
+			return resp, nil
+		}
+
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryBackoff returns the time to wait before retrying when the response does
+// not specify it, doubling from one second with each attempt up to 30 seconds.
+func retryBackoff(attempt int) time.Duration {
+	delay := time.Second << attempt
+	if delay <= 0 || delay > 30*time.Second {
+		delay = 30 * time.Second
+	}
+
+	return delay
+}
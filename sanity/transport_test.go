@@ -0,0 +1,318 @@
+package sanity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_RetriesRateLimitedRequests(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		var req QueryRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Query != "*" {
+			t.Errorf("Attempt %d: expected the body to be sent again, got query '%s'", attempts, req.Query)
+		}
+
+		w.Header().Set("X-RateLimit-Limit-Second", "25")
+		if attempts < 3 {
+			w.Header().Set("X-RateLimit-Remaining-Second", "0")
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"rate limit exceeded"}`))
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Remaining-Second", "24")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithRetries(2))
+	client.testProjectBaseURL = ts.URL
+
+	var docs []Document
+	if err := client.Documents.Query(context.Background(), "test-project", "production", &QueryRequest{Query: "*"}, &docs); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if rl := client.RateLimit(); rl.Limit != 25 || rl.Remaining != 24 {
+		t.Errorf("Unexpected rate limit %+v", rl)
+	}
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithRetries(1))
+	client.testProjectBaseURL = ts.URL
+
+	_, err := client.Documents.Get(context.Background(), "test-project", "production", "doc-1")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+	if rl := client.RateLimit(); rl.Remaining != 0 || rl.Reset.IsZero() {
+		t.Errorf("Expected the rate limit to be exhausted, got %+v", rl)
+	}
+}
+
+func TestClient_RetriedTransactionsAreIdempotent(t *testing.T) {
+	var transactionIds []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req MutateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		transactionIds = append(transactionIds, req.TransactionId)
+
+		if len(transactionIds) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"transactionId":"` + req.TransactionId + `","results":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithRetries(1))
+	client.testProjectBaseURL = ts.URL
+
+	r := &MutateRequest{Mutations: []Mutation{{Create: Document{"_type": "movie"}}}}
+	resp, err := client.Documents.Mutate(context.Background(), "test-project", "production", r)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(transactionIds) != 2 || transactionIds[0] == "" || transactionIds[0] != transactionIds[1] {
+		t.Errorf("Expected the same generated transaction id on each attempt, got %v", transactionIds)
+	}
+	if resp.TransactionId != transactionIds[0] {
+		t.Errorf("Expected transaction id '%s', got '%s'", transactionIds[0], resp.TransactionId)
+	}
+	if r.TransactionId != "" {
+		t.Errorf("Expected the request not to be modified, got transaction id '%s'", r.TransactionId)
+	}
+}
+
+func TestClient_NoRetriesByDefault(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.testProjectBaseURL = ts.URL
+
+	client.Documents.Get(context.Background(), "test-project", "production", "doc-1")
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestClient_DoesNotRetryStreamedBodies(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithRetries(3))
+	client.testProjectBaseURL = ts.URL
+
+	client.Assets.UploadFile(context.Background(), "test-project", "production", &UploadAssetRequest{
+		Body: struct{ *strings.Reader }{strings.NewReader("data")},
+	})
+
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithTimeout(50*time.Millisecond))
+	client.testProjectBaseURL = ts.URL
+
+	var docs []Document
+	err := client.Documents.Query(context.Background(), "test-project", "production", &QueryRequest{Query: "*"}, &docs)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClient_TimeoutDoesNotOverrideDeadline(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithTimeout(10*time.Millisecond))
+	client.testProjectBaseURL = ts.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var docs []Document
+	err := client.Documents.Query(ctx, "test-project", "production", &QueryRequest{Query: "*"}, &docs)
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestWithTransportOptions(t *testing.T) {
+	client := NewClient(nil, WithTransportOptions(TransportOptions{
+		MaxIdleConnsPerHost: 50,
+		IdleConnTimeout:     time.Minute,
+		DisableHTTP2:        true,
+	}))
+
+	base, ok := client.client.Transport.(*transport).base.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected an *http.Transport, got %T", client.client.Transport.(*transport).base)
+	}
+	if base == http.DefaultTransport {
+		t.Fatal("Expected http.DefaultTransport to be copied")
+	}
+	if base.MaxIdleConnsPerHost != 50 || base.IdleConnTimeout != time.Minute {
+		t.Errorf("Expected tuned connection pool, got %d idle conns per host and %s timeout", base.MaxIdleConnsPerHost, base.IdleConnTimeout)
+	}
+	if base.ForceAttemptHTTP2 || base.TLSNextProto == nil {
+		t.Error("Expected HTTP/2 to be disabled")
+	}
+	if http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost == 50 {
+		t.Error("Expected http.DefaultTransport not to be modified")
+	}
+}
+
+func TestWithTransportOptions_CustomTransport(t *testing.T) {
+	custom := &countingTransport{}
+	client := NewClient(&http.Client{Transport: custom}, WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 50}))
+
+	if client.client.Transport.(*transport).base != custom {
+		t.Error("Expected a custom transport to be used unchanged")
+	}
+
+	client.testProjectBaseURL = "https://test-project.api.sanity.io"
+	if err := client.Schedules.Delete(context.Background(), "test-project", "production", "sch-1"); err != nil || custom.requests != 1 {
+		t.Errorf("Expected the request to be sent with the custom transport, got %d requests (%v)", custom.requests, err)
+	}
+}
+
+// countingTransport is a transport that counts the requests it receives and
+// responds with 204 No Content.
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return &http.Response{StatusCode: http.StatusNoContent, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+}
+
+func TestClient_MaxConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, WithMaxConcurrency(2))
+	client.testProjectBaseURL = ts.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var docs []Document
+			if err := client.Documents.Query(context.Background(), "test-project", "production", &QueryRequest{Query: "*"}, &docs); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestClient_MaxConcurrencyRespectsContext(t *testing.T) {
+	client := NewClient(&http.Client{Transport: &countingTransport{}}, WithMaxConcurrency(1))
+	client.testProjectBaseURL = "https://test-project.api.sanity.io"
+
+	// Occupy the only slot with a response whose body is never closed.
+	req, _ := http.NewRequest(http.MethodGet, "https://test-project.api.sanity.io", nil)
+	if _, err := client.client.Transport.RoundTrip(req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := client.Schedules.Delete(ctx, "test-project", "production", "sch-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded while waiting for a slot, got %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if d, ok := retryAfter("3", now); !ok || d != 3*time.Second {
+		t.Errorf("Expected 3s, got %v (%v)", d, ok)
+	}
+	if d, ok := retryAfter("Mon, 01 Jan 2024 00:00:05 GMT", now); !ok || d != 5*time.Second {
+		t.Errorf("Expected 5s, got %v (%v)", d, ok)
+	}
+	if _, ok := retryAfter("soon", now); ok {
+		t.Error("Expected an invalid value to be rejected")
+	}
+}
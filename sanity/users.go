@@ -0,0 +1,55 @@
+package sanity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UsersService is a client for the Sanity Users API.
+//
+// Refer to https://www.sanity.io/docs/users-api for more information.
+type UsersService service
+
+// A CurrentUser is the identity the client is authenticated as.
+type CurrentUser struct {
+	// Id is the unique identifier for the user.
+	Id string `json:"id"`
+
+	// Name is the user's full name.
+	Name string `json:"name"`
+
+	// Email is the user's email address.
+	Email string `json:"email"`
+
+	// ProfileImage is a url pointing to an image for the user.
+	//
+	// The image is provided by the authentication provider of the user. The
+	// Users API does not support uploading or clearing profile images, so they
+	// cannot be managed with this client, including for robot tokens, which
+	// have no profile image.
+	ProfileImage string `json:"profileImage,omitempty"`
+
+	// Provider is the authentication provider the user logged in with, e.g.,
+	// `google`, `github`, or `sanity`.
+	Provider string `json:"provider"`
+
+	// Role is the name of the user's role on the project the request is scoped
+	// to, if any.
+	Role string `json:"role,omitempty"`
+
+	// Roles are the user's roles on the project the request is scoped to, if
+	// any.
+	Roles []Role `json:"roles,omitempty"`
+}
+
+// GetCurrent fetches and returns the user the client is authenticated as. This
+// is a cheap way to check that a token is valid.
+func (s *UsersService) GetCurrent(ctx context.Context) (*CurrentUser, error) {
+	url := fmt.Sprintf("%s/v2021-06-07/users/me", s.client.baseURL)
+
+	var user CurrentUser
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &user)
+
+	return &user, err
+}
@@ -0,0 +1,38 @@
+package sanity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUsersService_GetCurrent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2021-06-07/users/me" {
+			t.Errorf("Expected /v2021-06-07/users/me path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"user-1","name":"Ada Lovelace","email":"ada@example.com","provider":"google","roles":[{"name":"administrator","title":"Administrator"}]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+
+	user, err := client.Users.GetCurrent(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if user.Id != "user-1" || user.Email != "ada@example.com" || user.Provider != "google" {
+		t.Errorf("Unexpected user %+v", user)
+	}
+	if len(user.Roles) != 1 || user.Roles[0].Name != "administrator" {
+		t.Errorf("Unexpected roles %+v", user.Roles)
+	}
+}
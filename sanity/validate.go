@@ -0,0 +1,110 @@
+package sanity
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// A ValidationError describes an invalid value in a request. It is returned
+// before any request is sent to the API.
+type ValidationError struct {
+	// Field is the name of the invalid field.
+	Field string
+
+	// Value is the invalid value.
+	Value string
+
+	// Reason describes why the value is invalid.
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s '%s': %s", e.Field, e.Value, e.Reason)
+}
+
+// ValidationErrors describes all the invalid values in a request. It is
+// returned by the `Validate` methods of request types, which are called before
+// the request is sent to the API, so that every problem with a request can be
+// reported at once.
+//
+// errors.As finds the first *ValidationError in the list.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// As sets the target to the first *ValidationError in the list.
+func (e ValidationErrors) As(target any) bool {
+	t, ok := target.(**ValidationError)
+	if !ok || len(e) == 0 {
+		return false
+	}
+	*t = e[0]
+
+	return true
+}
+
+// validation collects the invalid values found by a `Validate` method.
+type validation struct {
+	errs ValidationErrors
+}
+
+// check records an invalid value for the field unless `valid` is true.
+func (v *validation) check(valid bool, field, value, reason string) {
+	if !valid {
+		v.errs = append(v.errs, &ValidationError{Field: field, Value: value, Reason: reason})
+	}
+}
+
+// require records the field as invalid if it is blank.
+func (v *validation) require(field, value string) {
+	v.check(value != "", field, value, field+" is required")
+}
+
+// url records the field as invalid if it is not an absolute HTTP or HTTPS URL.
+func (v *validation) url(field, value string) {
+	u, err := url.Parse(value)
+	valid := err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	v.check(valid, field, value, field+" must be an absolute http or https URL")
+}
+
+// oneOf records the field as invalid if it is set to a value that is not one
+// of `values`.
+func (v *validation) oneOf(field, value string, values ...string) {
+	if value == "" {
+		return
+	}
+	for _, s := range values {
+		if value == s {
+			return
+		}
+	}
+	v.check(false, field, value, fmt.Sprintf("%s must be one of %s", field, strings.Join(values, ", ")))
+}
+
+// add records the error returned by a validation function, such as
+// ValidateDatasetName.
+func (v *validation) add(err error) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		v.errs = append(v.errs, validationErr)
+	}
+}
+
+// err returns the recorded invalid values as ValidationErrors, or nil if there
+// are none.
+func (v *validation) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
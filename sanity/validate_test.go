@@ -0,0 +1,71 @@
+package sanity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidate_ReportsAllFields(t *testing.T) {
+	err := (&CreateWebhookRequest{URL: "example.com/hook", HttpMethod: "OPTIONS"}).Validate()
+
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	var fields []string
+	for _, e := range validationErrs {
+		fields = append(fields, e.Field)
+	}
+	if len(fields) != 3 || fields[0] != "name" || fields[1] != "url" || fields[2] != "http method" {
+		t.Errorf("Expected name, url, and http method to be invalid, got %v", fields)
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "name" {
+		t.Errorf("Expected the first ValidationError, got %v", validationErr)
+	}
+}
+
+func TestValidate_ValidRequests(t *testing.T) {
+	requests := []interface{ Validate() error }{
+		&CreateDatasetRequest{Name: "staging", AclMode: AclModePrivate},
+		&EditDatasetRequest{AclMode: AclModePublic},
+		&CopyDatasetRequest{SourceDataset: "production", TargetDataset: "staging"},
+		&CreateProjectTokenRequest{Label: "CI", RoleName: "viewer"},
+		&CreateDatasetTagRequest{Name: "env", Title: "Environment", Tone: TonePrimary},
+		&EditDatasetTagRequest{Title: "Environment"},
+		&CreateWebhookRequest{Name: "Notify", URL: "https://example.com/hook", HttpMethod: http.MethodPost},
+		&UpdateWebhookRequest{Name: "Notify"},
+		&CreateLegacyWebhookRequest{Name: "Notify", Dataset: "production", URL: "https://example.com/hook"},
+	}
+
+	for _, r := range requests {
+		if err := r.Validate(); err != nil {
+			t.Errorf("Expected %T to be valid, got %v", r, err)
+		}
+	}
+}
+
+func TestValidate_BeforeRequest(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	client := NewClient(nil)
+	client.baseURL = ts.URL
+
+	_, err := client.Projects.CreateDatasetTag(context.Background(), "test-project", &CreateDatasetTagRequest{Tone: "purple"})
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 3 {
+		t.Errorf("Expected name, title, and tone to be invalid, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests, got %d", requests)
+	}
+}
@@ -2,8 +2,12 @@ package sanity
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -33,7 +37,7 @@ func (s *WebhooksService) getWebhookBaseURL(projectId string) string {
 	if s.testBaseURL != "" {
 		return s.testBaseURL
 	}
-	return fmt.Sprintf("https://%s.api.sanity.io/v2025-02-19", projectId)
+	return s.client.projectHost.url(projectId) + "/v2025-02-19"
 }
 
 // A Webhook represents a webhook configuration for a Sanity project.
@@ -50,6 +54,9 @@ type Webhook struct {
 	// Name is the human-readable name for the webhook.
 	Name string `json:"name"`
 
+	// Description is a longer description of the webhook.
+	Description string `json:"description,omitempty"`
+
 	// Dataset is the dataset this webhook is configured for.
 	Dataset string `json:"dataset"`
 
@@ -65,6 +72,10 @@ type Webhook struct {
 	// IncludeDrafts indicates whether draft documents trigger webhook notifications.
 	IncludeDrafts bool `json:"includeDrafts"`
 
+	// IncludeVersions indicates whether version documents in releases trigger
+	// webhook notifications.
+	IncludeVersions bool `json:"includeVersions"`
+
 	// Headers are custom HTTP headers sent with webhook requests.
 	Headers map[string]string `json:"headers,omitempty"`
 
@@ -82,6 +93,16 @@ type Webhook struct {
 
 	// IsDisabled indicates whether the webhook is currently disabled.
 	IsDisabled bool `json:"isDisabled"`
+
+	// IsDisabledByUser indicates whether the webhook was disabled by a user, as
+	// opposed to by Sanity, e.g., after repeated delivery failures.
+	IsDisabledByUser bool `json:"isDisabledByUser"`
+
+	// CreatedByUserId is the identifier of the user who created the webhook.
+	CreatedByUserId string `json:"createdByUserId,omitempty"`
+
+	// DeletedAt is the time the webhook was deleted, if it has been.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
 
 // CreateWebhookRequest represents the payload for creating a new webhook.
@@ -95,6 +116,9 @@ type CreateWebhookRequest struct {
 	// Dataset is the dataset this webhook is configured for.
 	Dataset string `json:"dataset"`
 
+	// Description is a longer description of the webhook.
+	Description string `json:"description,omitempty"`
+
 	// URL is the endpoint that will receive webhook notifications.
 	URL string `json:"url"`
 
@@ -107,6 +131,10 @@ type CreateWebhookRequest struct {
 	// IncludeDrafts indicates whether draft documents trigger webhook notifications.
 	IncludeDrafts *bool `json:"includeDrafts,omitempty"`
 
+	// IncludeVersions indicates whether version documents in releases trigger
+	// webhook notifications.
+	IncludeVersions *bool `json:"includeVersions,omitempty"`
+
 	// Headers are custom HTTP headers sent with webhook requests.
 	Headers map[string]string `json:"headers,omitempty"`
 
@@ -120,6 +148,20 @@ type CreateWebhookRequest struct {
 	IsDisabledByUser *bool `json:"isDisabledByUser,omitempty"`
 }
 
+// webhookMethods are the HTTP methods webhooks can send requests with.
+var webhookMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *CreateWebhookRequest) Validate() error {
+	var v validation
+	v.require("name", r.Name)
+	v.url("url", r.URL)
+	v.oneOf("http method", r.HttpMethod, webhookMethods...)
+
+	return v.err()
+}
+
 // UpdateWebhookRequest represents the payload for updating an existing webhook.
 type UpdateWebhookRequest struct {
 	// Type is the type of the webhook.
@@ -128,6 +170,9 @@ type UpdateWebhookRequest struct {
 	// Name is the human-readable name for the webhook.
 	Name string `json:"name,omitempty"`
 
+	// Description is a longer description of the webhook.
+	Description string `json:"description,omitempty"`
+
 	// URL is the endpoint that will receive webhook notifications.
 	URL string `json:"url,omitempty"`
 
@@ -140,6 +185,10 @@ type UpdateWebhookRequest struct {
 	// IncludeDrafts indicates whether draft documents trigger webhook notifications.
 	IncludeDrafts *bool `json:"includeDrafts,omitempty"`
 
+	// IncludeVersions indicates whether version documents in releases trigger
+	// webhook notifications.
+	IncludeVersions *bool `json:"includeVersions,omitempty"`
+
 	// Headers are custom HTTP headers sent with webhook requests.
 	Headers map[string]string `json:"headers,omitempty"`
 
@@ -163,8 +212,73 @@ func (s *WebhooksService) List(ctx context.Context, projectId string) ([]Webhook
 	return webhooks, err
 }
 
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values. Blank fields are left
+// unchanged by an update, so only the fields that are set are checked.
+func (r *UpdateWebhookRequest) Validate() error {
+	var v validation
+	if r.URL != "" {
+		v.url("url", r.URL)
+	}
+	v.oneOf("http method", r.HttpMethod, webhookMethods...)
+
+	return v.err()
+}
+
+// ListWebhooksRequest describes filters for listing webhooks. The filters are
+// applied on the client, as the API does not support them.
+type ListWebhooksRequest struct {
+	// Dataset filters the webhooks to those configured for the dataset.
+	Dataset string
+
+	// Enabled filters the webhooks to those that are enabled, if true, or
+	// disabled, if false. A webhook is disabled if it was disabled either by a
+	// user or by Sanity.
+	Enabled *bool
+
+	// URLContains filters the webhooks to those whose URL contains the text.
+	URLContains string
+}
+
+// matches reports whether the webhook passes the filters of the request.
+func (r *ListWebhooksRequest) matches(webhook *Webhook) bool {
+	if r.Dataset != "" && webhook.Dataset != r.Dataset {
+		return false
+	}
+	if r.Enabled != nil && *r.Enabled == (webhook.IsDisabled || webhook.IsDisabledByUser) {
+		return false
+	}
+	if r.URLContains != "" && !strings.Contains(webhook.URL, r.URLContains) {
+		return false
+	}
+
+	return true
+}
+
+// ListFiltered fetches and returns the webhooks for the specified project that
+// match the filters.
+func (s *WebhooksService) ListFiltered(ctx context.Context, projectId string, r *ListWebhooksRequest) ([]Webhook, error) {
+	webhooks, err := s.List(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]Webhook, 0, len(webhooks))
+	for i := range webhooks {
+		if r.matches(&webhooks[i]) {
+			filtered = append(filtered, webhooks[i])
+		}
+	}
+
+	return filtered, nil
+}
+
 // Create generates a new webhook for the specified project.
 func (s *WebhooksService) Create(ctx context.Context, projectId string, r *CreateWebhookRequest) (*Webhook, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/hooks/projects/%s", s.getWebhookBaseURL(projectId), projectId)
 
 	var webhook Webhook
@@ -185,6 +299,10 @@ func (s *WebhooksService) Get(ctx context.Context, projectId, webhookId string)
 
 // Update applies the requested changes to the specified webhook.
 func (s *WebhooksService) Update(ctx context.Context, projectId, webhookId string, r *UpdateWebhookRequest) (*Webhook, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("%s/hooks/projects/%s/%s", s.getWebhookBaseURL(projectId), projectId, webhookId)
 
 	var webhook Webhook
@@ -193,6 +311,53 @@ func (s *WebhooksService) Update(ctx context.Context, projectId, webhookId strin
 	return &webhook, err
 }
 
+// Disable stops the specified webhook from sending notifications until it is
+// enabled again.
+func (s *WebhooksService) Disable(ctx context.Context, projectId, webhookId string) (*Webhook, error) {
+	return s.Update(ctx, projectId, webhookId, &UpdateWebhookRequest{IsDisabledByUser: NewBool(true)})
+}
+
+// Enable resumes notifications from the specified webhook after it was
+// disabled.
+func (s *WebhooksService) Enable(ctx context.Context, projectId, webhookId string) (*Webhook, error) {
+	return s.Update(ctx, projectId, webhookId, &UpdateWebhookRequest{IsDisabledByUser: NewBool(false)})
+}
+
+type RotateWebhookSecretResponse struct {
+	// Webhook is the updated webhook.
+	Webhook *Webhook
+
+	// OldSecret is the secret the webhook used before the rotation. It is
+	// empty if the webhook had no secret.
+	OldSecret string
+
+	// NewSecret is the secret the webhook uses from now on.
+	NewSecret string
+}
+
+// RotateSecret generates a new random secret for the specified webhook and
+// returns both the old and the new secret, so that receivers can accept
+// signatures made with either secret while they are updated.
+func (s *WebhooksService) RotateSecret(ctx context.Context, projectId, webhookId string) (*RotateWebhookSecretResponse, error) {
+	current, err := s.Get(ctx, projectId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	secret := hex.EncodeToString(b)
+
+	webhook, err := s.Update(ctx, projectId, webhookId, &UpdateWebhookRequest{Secret: secret})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotateWebhookSecretResponse{Webhook: webhook, OldSecret: current.Secret, NewSecret: secret}, nil
+}
+
 // Delete removes the specified webhook without prompt.
 func (s *WebhooksService) Delete(ctx context.Context, projectId, webhookId string) (bool, error) {
 	url := fmt.Sprintf("%s/hooks/projects/%s/%s", s.getWebhookBaseURL(projectId), projectId, webhookId)
@@ -205,3 +370,241 @@ func (s *WebhooksService) Delete(ctx context.Context, projectId, webhookId strin
 	err := do(ctx, s.client.client, url, http.MethodDelete, nil, &resp)
 	return resp.Deleted, err
 }
+
+// A WebhookAttempt is an attempt to deliver a webhook message.
+type WebhookAttempt struct {
+	// Id is the unique identifier for the attempt.
+	Id string `json:"id"`
+
+	// ProjectId is the identifier of the project the webhook belongs to.
+	ProjectId string `json:"projectId"`
+
+	// HookId is the identifier of the webhook.
+	HookId string `json:"hookId"`
+
+	// MessageId is the identifier of the message that was delivered.
+	MessageId string `json:"messageId"`
+
+	// InProgress indicates whether the attempt is still running.
+	InProgress bool `json:"inProgress"`
+
+	// Duration is the time in milliseconds the receiving endpoint took to
+	// respond.
+	Duration int64 `json:"duration"`
+
+	// IsFailure indicates whether the delivery failed.
+	IsFailure bool `json:"isFailure"`
+
+	// FailureReason describes why the delivery failed, e.g., `http` for an
+	// unsuccessful status code or `timeout`.
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// ResultCode is the HTTP status code returned by the receiving endpoint.
+	ResultCode int `json:"resultCode"`
+
+	// ResultBody is the body returned by the receiving endpoint.
+	ResultBody string `json:"resultBody,omitempty"`
+
+	// CreatedAt is the time the attempt was started.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// UpdatedAt is the time the attempt was last updated.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ListAttempts fetches and returns the delivery attempts of the specified
+// webhook, most recent first.
+func (s *WebhooksService) ListAttempts(ctx context.Context, projectId, webhookId string) ([]WebhookAttempt, error) {
+	url := fmt.Sprintf("%s/hooks/projects/%s/%s/attempts", s.getWebhookBaseURL(projectId), projectId, webhookId)
+
+	var attempts []WebhookAttempt
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &attempts)
+
+	return attempts, err
+}
+
+// IterateAttempts returns an iterator over the delivery attempts of the
+// specified webhook, most recent first.
+func (s *WebhooksService) IterateAttempts(projectId, webhookId string) *Iterator[WebhookAttempt] {
+	return singlePage(func(ctx context.Context) ([]WebhookAttempt, error) {
+		return s.ListAttempts(ctx, projectId, webhookId)
+	}).Items()
+}
+
+// RetryMessage redelivers the specified message of a webhook, e.g., after the
+// receiving endpoint has been fixed. The outcome of the delivery is reported as
+// a new attempt by ListAttempts.
+func (s *WebhooksService) RetryMessage(ctx context.Context, projectId, webhookId, messageId string) error {
+	url := fmt.Sprintf("%s/hooks/projects/%s/%s/messages/%s/retry", s.getWebhookBaseURL(projectId), projectId, webhookId, messageId)
+
+	var response map[string]any
+	return do(ctx, s.client.client, url, http.MethodPost, nil, &response)
+}
+
+// A LegacyWebhook is a legacy webhook, which notifies an endpoint of every
+// transaction in a dataset. Legacy webhooks predate GROQ-powered webhooks and
+// have no rules, projections, or custom methods and headers.
+type LegacyWebhook struct {
+	// Id is the unique identifier for the webhook.
+	Id string `json:"id"`
+
+	// ProjectId is the identifier of the project this webhook belongs to.
+	ProjectId string `json:"projectId"`
+
+	// Type is the type of the webhook, which is `transaction` for legacy
+	// webhooks.
+	Type string `json:"type"`
+
+	// Name is the human-readable name for the webhook.
+	Name string `json:"name"`
+
+	// Dataset is the dataset this webhook is configured for.
+	Dataset string `json:"dataset"`
+
+	// URL is the endpoint that will receive webhook notifications.
+	URL string `json:"url"`
+
+	// CreatedAt is the time the webhook was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateLegacyWebhookRequest represents the payload for creating a new legacy
+// webhook.
+type CreateLegacyWebhookRequest struct {
+	// Name is the human-readable name for the webhook.
+	Name string `json:"name"`
+
+	// Dataset is the dataset this webhook is configured for.
+	Dataset string `json:"dataset"`
+
+	// URL is the endpoint that will receive webhook notifications.
+	URL string `json:"url"`
+}
+
+// Validate checks the request before it is sent to the API, and returns
+// ValidationErrors describing any invalid values.
+func (r *CreateLegacyWebhookRequest) Validate() error {
+	var v validation
+	v.require("name", r.Name)
+	v.require("dataset", r.Dataset)
+	v.url("url", r.URL)
+
+	return v.err()
+}
+
+// ListLegacy fetches and returns all legacy webhooks for the specified project.
+func (s *WebhooksService) ListLegacy(ctx context.Context, projectId string) ([]LegacyWebhook, error) {
+	url := fmt.Sprintf("%s/v1/hooks/projects/%s", s.client.baseURL, projectId)
+
+	var webhooks []LegacyWebhook
+	err := do(ctx, s.client.client, url, http.MethodGet, nil, &webhooks)
+
+	return webhooks, err
+}
+
+// CreateLegacy generates a new legacy webhook for the specified project.
+func (s *WebhooksService) CreateLegacy(ctx context.Context, projectId string, r *CreateLegacyWebhookRequest) (*LegacyWebhook, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/v1/hooks/projects/%s", s.client.baseURL, projectId)
+
+	var webhook LegacyWebhook
+	err := do(ctx, s.client.client, url, http.MethodPost, r, &webhook)
+
+	return &webhook, err
+}
+
+// DeleteLegacy removes the specified legacy webhook without prompt.
+func (s *WebhooksService) DeleteLegacy(ctx context.Context, projectId, webhookId string) (bool, error) {
+	url := fmt.Sprintf("%s/v1/hooks/projects/%s/%s", s.client.baseURL, projectId, webhookId)
+
+	type response struct {
+		Deleted bool `json:"deleted"`
+	}
+
+	var resp response
+	err := do(ctx, s.client.client, url, http.MethodDelete, nil, &resp)
+	return resp.Deleted, err
+}
+
+// WebhookHealth summarizes the recent delivery attempts of a webhook.
+type WebhookHealth struct {
+	// Attempts is the number of completed attempts summarized.
+	Attempts int
+
+	// Failures is the number of failed attempts.
+	Failures int
+
+	// SuccessRate is the fraction of attempts that succeeded, between 0 and 1.
+	// It is 1 if there are no attempts.
+	SuccessRate float64
+
+	// ConsecutiveFailures is the number of attempts that failed since the last
+	// successful attempt.
+	ConsecutiveFailures int
+
+	// LastSuccessAt is the time of the last successful attempt, or the zero
+	// time if no attempt succeeded.
+	LastSuccessAt time.Time
+
+	// LastFailureAt is the time of the last failed attempt, or the zero time if
+	// no attempt failed.
+	LastFailureAt time.Time
+
+	// LastFailureReason is the failure reason of the last failed attempt.
+	LastFailureReason string
+}
+
+// SummarizeAttempts aggregates delivery attempts into a health summary.
+// Attempts that are still in progress are ignored.
+func SummarizeAttempts(attempts []WebhookAttempt) WebhookHealth {
+	completed := make([]WebhookAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		if !attempt.InProgress {
+			completed = append(completed, attempt)
+		}
+	}
+	sort.SliceStable(completed, func(i, j int) bool {
+		return completed[i].CreatedAt.After(completed[j].CreatedAt)
+	})
+
+	health := WebhookHealth{Attempts: len(completed), SuccessRate: 1}
+	succeeded := false
+	for _, attempt := range completed {
+		if !attempt.IsFailure {
+			if !succeeded {
+				health.LastSuccessAt = attempt.CreatedAt
+				succeeded = true
+			}
+			continue
+		}
+
+		health.Failures++
+		if !succeeded {
+			health.ConsecutiveFailures++
+		}
+		if health.LastFailureAt.IsZero() {
+			health.LastFailureAt = attempt.CreatedAt
+			health.LastFailureReason = attempt.FailureReason
+		}
+	}
+	if health.Attempts > 0 {
+		health.SuccessRate = float64(health.Attempts-health.Failures) / float64(health.Attempts)
+	}
+
+	return health
+}
+
+// Health fetches the recent delivery attempts of the specified webhook and
+// returns a summary of them, e.g., for use in monitoring checks.
+func (s *WebhooksService) Health(ctx context.Context, projectId, webhookId string) (*WebhookHealth, error) {
+	attempts, err := s.ListAttempts(ctx, projectId, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	health := SummarizeAttempts(attempts)
+	return &health, nil
+}
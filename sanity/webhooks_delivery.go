@@ -0,0 +1,105 @@
+package sanity
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	WebhookOperationCreate = "create"
+	WebhookOperationUpdate = "update"
+	WebhookOperationDelete = "delete"
+)
+
+// A WebhookDelivery is a request sent by a GROQ-powered webhook to its
+// receiving endpoint.
+type WebhookDelivery struct {
+	// WebhookId is the identifier of the webhook that sent the request.
+	WebhookId string
+
+	// ProjectId is the identifier of the project of the changed document.
+	ProjectId string
+
+	// Dataset is the dataset of the changed document.
+	Dataset string
+
+	// DocumentId is the identifier of the changed document.
+	DocumentId string
+
+	// Operation is the change that triggered the webhook. Valid values are
+	// represented as the `WebhookOperation*` constants in this package.
+	Operation string
+
+	// TransactionId is the identifier of the transaction that changed the
+	// document.
+	TransactionId string
+
+	// TransactionTime is the time the transaction was committed.
+	TransactionTime time.Time
+
+	// IdempotencyKey is a key that is unique to the delivered message and is
+	// the same for all delivery attempts of it. Use it to skip messages that
+	// were already processed.
+	IdempotencyKey string
+
+	// Body is the payload of the request, i.e., the document as shaped by the
+	// projection of the webhook.
+	Body json.RawMessage
+}
+
+// Document decodes the payload as a document. This is suitable for webhooks
+// without a projection, which deliver the full document.
+func (d *WebhookDelivery) Document() (Document, error) {
+	var doc Document
+	err := d.Decode(&doc)
+
+	return doc, err
+}
+
+// Decode decodes the payload into `v`, e.g., a struct that matches the
+// projection of the webhook.
+func (d *WebhookDelivery) Decode(v any) error {
+	if len(d.Body) == 0 {
+		return errors.New("webhook delivery has no body")
+	}
+
+	return json.Unmarshal(d.Body, v)
+}
+
+// ParseWebhookRequest reads a request sent by a GROQ-powered webhook and
+// returns the delivery it describes. The metadata of the delivery is read from
+// the `sanity-*` headers of the request.
+//
+// The request body is consumed. The signature of the request is not verified.
+func ParseWebhookRequest(r *http.Request) (*WebhookDelivery, error) {
+	if r.Header.Get("sanity-webhook-id") == "" {
+		return nil, errors.New("request is not a sanity webhook delivery")
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &WebhookDelivery{
+		WebhookId:      r.Header.Get("sanity-webhook-id"),
+		ProjectId:      r.Header.Get("sanity-project-id"),
+		Dataset:        r.Header.Get("sanity-dataset"),
+		DocumentId:     r.Header.Get("sanity-document-id"),
+		Operation:      r.Header.Get("sanity-operation"),
+		TransactionId:  r.Header.Get("sanity-transaction-id"),
+		IdempotencyKey: r.Header.Get("idempotency-key"),
+		Body:           body,
+	}
+	if t := r.Header.Get("sanity-transaction-time"); t != "" {
+		delivery.TransactionTime, err = time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return delivery, nil
+}
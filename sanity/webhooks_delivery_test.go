@@ -0,0 +1,55 @@
+package sanity
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWebhookRequest(t *testing.T) {
+	req := httptest.NewRequest("POST", "/hooks/sanity", strings.NewReader(`{"_id":"post_1","_type":"post","_rev":"rev-2","title":"Hello"}`))
+	req.Header.Set("sanity-webhook-id", "webhook1")
+	req.Header.Set("sanity-project-id", "test-project")
+	req.Header.Set("sanity-dataset", "production")
+	req.Header.Set("sanity-document-id", "post_1")
+	req.Header.Set("sanity-operation", "update")
+	req.Header.Set("sanity-transaction-id", "rev-2")
+	req.Header.Set("sanity-transaction-time", "2024-01-02T03:04:05.123Z")
+	req.Header.Set("idempotency-key", "idem-1")
+
+	delivery, err := ParseWebhookRequest(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if delivery.WebhookId != "webhook1" || delivery.Operation != WebhookOperationUpdate || delivery.IdempotencyKey != "idem-1" {
+		t.Errorf("Unexpected delivery %+v", delivery)
+	}
+	if !delivery.TransactionTime.Equal(time.Date(2024, 1, 2, 3, 4, 5, 123000000, time.UTC)) {
+		t.Errorf("Unexpected transaction time %v", delivery.TransactionTime)
+	}
+
+	doc, err := delivery.Document()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if doc.Id() != "post_1" || doc.Rev() != "rev-2" {
+		t.Errorf("Unexpected document %v", doc)
+	}
+
+	var post struct {
+		Title string `json:"title"`
+	}
+	if err := delivery.Decode(&post); err != nil || post.Title != "Hello" {
+		t.Errorf("Expected title 'Hello', got '%s' (%v)", post.Title, err)
+	}
+}
+
+func TestParseWebhookRequest_NotAWebhook(t *testing.T) {
+	req := httptest.NewRequest("POST", "/hooks/sanity", strings.NewReader(`{}`))
+
+	if _, err := ParseWebhookRequest(req); err == nil {
+		t.Error("Expected an error for a request without webhook headers")
+	}
+}
@@ -45,7 +45,7 @@ func TestWebhooksService_List(t *testing.T) {
 
 	// Create a client and set test base URL
 	client := NewClient(http.DefaultClient)
-	client.Webhooks.testBaseURL = ts.URL
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
 
 	// Test the List method
 	ctx := context.Background()
@@ -110,7 +110,7 @@ func TestWebhooksService_Create(t *testing.T) {
 
 	// Create a client and set test base URL
 	client := NewClient(http.DefaultClient)
-	client.Webhooks.testBaseURL = ts.URL
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
 
 	// Test the Create method
 	ctx := context.Background()
@@ -157,7 +157,7 @@ func TestClient_WebhooksService(t *testing.T) {
 		t.Fatal("Expected Webhooks service to be initialized")
 	}
 
-	if client.Webhooks.client != client {
+	if client.Webhooks.(*WebhooksService).client != client {
 		t.Error("Expected Webhooks service to have reference to client")
 	}
 }
@@ -305,7 +305,7 @@ func TestWebhookService_BaseURL(t *testing.T) {
 	
 	// Test the base URL format
 	expectedURL := "https://test-project.api.sanity.io/v2025-02-19"
-	actualURL := client.Webhooks.getWebhookBaseURL("test-project")
+	actualURL := client.Webhooks.(*WebhooksService).getWebhookBaseURL("test-project")
 	
 	if actualURL != expectedURL {
 		t.Errorf("Expected base URL '%s', got '%s'", expectedURL, actualURL)
@@ -313,15 +313,15 @@ func TestWebhookService_BaseURL(t *testing.T) {
 	
 	// Test with different project ID
 	expectedURL2 := "https://my-project-123.api.sanity.io/v2025-02-19"
-	actualURL2 := client.Webhooks.getWebhookBaseURL("my-project-123")
+	actualURL2 := client.Webhooks.(*WebhooksService).getWebhookBaseURL("my-project-123")
 	
 	if actualURL2 != expectedURL2 {
 		t.Errorf("Expected base URL '%s', got '%s'", expectedURL2, actualURL2)
 	}
 	
 	// Test that testBaseURL takes precedence
-	client.Webhooks.testBaseURL = "http://localhost:8080"
-	testURL := client.Webhooks.getWebhookBaseURL("any-project")
+	client.Webhooks.(*WebhooksService).testBaseURL = "http://localhost:8080"
+	testURL := client.Webhooks.(*WebhooksService).getWebhookBaseURL("any-project")
 	
 	if testURL != "http://localhost:8080" {
 		t.Errorf("Expected test base URL 'http://localhost:8080', got '%s'", testURL)
@@ -340,7 +340,7 @@ func TestWebhookService_FullURLConstruction(t *testing.T) {
 
 	// Create a client and set test base URL
 	client := NewClient(http.DefaultClient)
-	client.Webhooks.testBaseURL = ts.URL
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
 
 	// Call List method
 	ctx := context.Background()
@@ -356,16 +356,301 @@ func TestWebhookService_FullURLConstruction(t *testing.T) {
 	}
 
 	// Without testBaseURL override, verify the URL would be project-specific
-	client.Webhooks.testBaseURL = ""
+	client.Webhooks.(*WebhooksService).testBaseURL = ""
 	expectedBaseURL := "https://test-project.api.sanity.io/v2025-02-19"
 	expectedFullURL := expectedBaseURL + "/hooks/projects/test-project"
 	
 	// We can't easily test the actual HTTP call without making real requests,
 	// but we can verify the URL construction logic
-	baseURL := client.Webhooks.getWebhookBaseURL("test-project")
+	baseURL := client.Webhooks.(*WebhooksService).getWebhookBaseURL("test-project")
 	fullURL := fmt.Sprintf("%s/hooks/projects/%s", baseURL, "test-project")
 	
 	if fullURL != expectedFullURL {
 		t.Errorf("Expected full URL '%s', got '%s'", expectedFullURL, fullURL)
 	}
 }
+
+func TestWebhooksService_ListAttempts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/hooks/projects/test-project/webhook1/attempts" {
+			t.Errorf("Expected /hooks/projects/test-project/webhook1/attempts path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"attempt1","hookId":"webhook1","messageId":"msg1","duration":1500,"isFailure":true,"failureReason":"http","resultCode":502,"resultBody":"Bad Gateway"}]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
+
+	attempts, err := client.Webhooks.ListAttempts(context.Background(), "test-project", "webhook1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(attempts) != 1 {
+		t.Fatalf("Expected 1 attempt, got %d", len(attempts))
+	}
+	attempt := attempts[0]
+	if !attempt.IsFailure || attempt.ResultCode != 502 || attempt.MessageId != "msg1" || attempt.Duration != 1500 {
+		t.Errorf("Unexpected attempt %+v", attempt)
+	}
+}
+
+func TestWebhooksService_RetryMessage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/hooks/projects/test-project/webhook1/messages/msg1/retry" {
+			t.Errorf("Expected /hooks/projects/test-project/webhook1/messages/msg1/retry path, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"msg1"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
+
+	if err := client.Webhooks.RetryMessage(context.Background(), "test-project", "webhook1", "msg1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestWebhooksService_Get_FullModel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "webhook1",
+			"name": "Publish hook",
+			"description": "Notifies the site of published changes",
+			"rule": {"on": ["create", "update"], "filter": "_type == 'post'", "projection": "{_id, title}"},
+			"includeVersions": true,
+			"isDisabled": true,
+			"isDisabledByUser": false,
+			"createdByUserId": "user-1"
+		}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
+
+	webhook, err := client.Webhooks.Get(context.Background(), "test-project", "webhook1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if webhook.Description != "Notifies the site of published changes" || !webhook.IncludeVersions {
+		t.Errorf("Unexpected webhook %+v", webhook)
+	}
+	if webhook.Rule == nil || len(webhook.Rule.On) != 2 || webhook.Rule.Projection != "{_id, title}" {
+		t.Errorf("Unexpected rule %+v", webhook.Rule)
+	}
+	if !webhook.IsDisabled || webhook.IsDisabledByUser || webhook.CreatedByUserId != "user-1" || webhook.DeletedAt != nil {
+		t.Errorf("Unexpected status fields %+v", webhook)
+	}
+}
+
+func TestWebhooksService_Legacy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/hooks/projects/test-project":
+			w.Write([]byte(`[{"id":"hook1","projectId":"test-project","type":"transaction","name":"Legacy","dataset":"production","url":"https://example.com/hook"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/hooks/projects/test-project":
+			var req CreateLegacyWebhookRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "Legacy" || req.Dataset != "production" {
+				t.Errorf("Unexpected request %+v", req)
+			}
+			w.Write([]byte(`{"id":"hook2","type":"transaction","name":"Legacy","dataset":"production"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/hooks/projects/test-project/hook2":
+			w.Write([]byte(`{"deleted":true}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.baseURL = ts.URL
+	ctx := context.Background()
+
+	webhooks, err := client.Webhooks.ListLegacy(ctx, "test-project")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(webhooks) != 1 || webhooks[0].Type != "transaction" {
+		t.Errorf("Unexpected webhooks %+v", webhooks)
+	}
+
+	webhook, err := client.Webhooks.CreateLegacy(ctx, "test-project", &CreateLegacyWebhookRequest{
+		Name:    "Legacy",
+		Dataset: "production",
+		URL:     "https://example.com/hook",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if webhook.Id != "hook2" {
+		t.Errorf("Expected webhook ID 'hook2', got '%s'", webhook.Id)
+	}
+
+	deleted, err := client.Webhooks.DeleteLegacy(ctx, "test-project", "hook2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !deleted {
+		t.Error("Expected the webhook to be deleted")
+	}
+}
+
+func TestWebhooksService_DisableAndEnable(t *testing.T) {
+	var disabled any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected PATCH method, got %s", r.Method)
+		}
+
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 {
+			t.Errorf("Expected only isDisabledByUser to be sent, got %v", body)
+		}
+		disabled = body["isDisabledByUser"]
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"webhook1","isDisabledByUser":%v}`, disabled)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
+	ctx := context.Background()
+
+	webhook, err := client.Webhooks.Disable(ctx, "test-project", "webhook1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if disabled != true || !webhook.IsDisabledByUser {
+		t.Errorf("Expected the webhook to be disabled, got %+v", webhook)
+	}
+
+	webhook, err = client.Webhooks.Enable(ctx, "test-project", "webhook1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if disabled != false || webhook.IsDisabledByUser {
+		t.Errorf("Expected the webhook to be enabled, got %+v", webhook)
+	}
+}
+
+func TestWebhooksService_RotateSecret(t *testing.T) {
+	secret := "old-secret"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			var req UpdateWebhookRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			secret = req.Secret
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"webhook1","secret":%q}`, secret)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
+
+	resp, err := client.Webhooks.RotateSecret(context.Background(), "test-project", "webhook1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.OldSecret != "old-secret" {
+		t.Errorf("Expected old secret 'old-secret', got '%s'", resp.OldSecret)
+	}
+	if len(resp.NewSecret) != 64 || resp.NewSecret != secret || resp.Webhook.Secret != secret {
+		t.Errorf("Expected the new secret to be applied, got %+v", resp)
+	}
+}
+
+func TestSummarizeAttempts(t *testing.T) {
+	at := func(minute int) time.Time {
+		return time.Date(2024, 1, 1, 0, minute, 0, 0, time.UTC)
+	}
+	attempts := []WebhookAttempt{
+		{Id: "5", CreatedAt: at(5), InProgress: true},
+		{Id: "4", CreatedAt: at(4), IsFailure: true, FailureReason: "timeout"},
+		{Id: "3", CreatedAt: at(3), IsFailure: true, FailureReason: "http"},
+		{Id: "2", CreatedAt: at(2)},
+		{Id: "1", CreatedAt: at(1), IsFailure: true, FailureReason: "http"},
+	}
+
+	health := SummarizeAttempts(attempts)
+
+	if health.Attempts != 4 || health.Failures != 3 || health.SuccessRate != 0.25 {
+		t.Errorf("Unexpected counts %+v", health)
+	}
+	if health.ConsecutiveFailures != 2 {
+		t.Errorf("Expected 2 consecutive failures, got %d", health.ConsecutiveFailures)
+	}
+	if !health.LastSuccessAt.Equal(at(2)) || !health.LastFailureAt.Equal(at(4)) || health.LastFailureReason != "timeout" {
+		t.Errorf("Unexpected last attempts %+v", health)
+	}
+
+	if empty := SummarizeAttempts(nil); empty.SuccessRate != 1 || empty.Attempts != 0 {
+		t.Errorf("Unexpected summary of no attempts %+v", empty)
+	}
+}
+
+func TestWebhooksService_ListFiltered(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id":"webhook1","dataset":"production","url":"https://example.com/hooks/a"},
+			{"id":"webhook2","dataset":"production","url":"https://example.com/hooks/b","isDisabledByUser":true},
+			{"id":"webhook3","dataset":"staging","url":"https://example.com/hooks/c"},
+			{"id":"webhook4","dataset":"production","url":"https://other.example.com/hook","isDisabled":true}
+		]`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient)
+	client.Webhooks.(*WebhooksService).testBaseURL = ts.URL
+	ctx := context.Background()
+
+	tests := []struct {
+		request  ListWebhooksRequest
+		expected string
+	}{
+		{ListWebhooksRequest{}, "webhook1,webhook2,webhook3,webhook4"},
+		{ListWebhooksRequest{Dataset: "production"}, "webhook1,webhook2,webhook4"},
+		{ListWebhooksRequest{Enabled: NewBool(true)}, "webhook1,webhook3"},
+		{ListWebhooksRequest{Dataset: "production", Enabled: NewBool(false)}, "webhook2,webhook4"},
+		{ListWebhooksRequest{URLContains: "example.com/hooks"}, "webhook1,webhook2,webhook3"},
+	}
+
+	for _, test := range tests {
+		webhooks, err := client.Webhooks.ListFiltered(ctx, "test-project", &test.request)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		ids := make([]string, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			ids = append(ids, webhook.Id)
+		}
+		if strings.Join(ids, ",") != test.expected {
+			t.Errorf("Expected webhooks %s for %+v, got %v", test.expected, test.request, ids)
+		}
+	}
+}
@@ -0,0 +1,593 @@
+package sanitytest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A query is a parsed GROQ query of the subset supported by the server:
+//
+//	*[filter] | order(attribute asc, ...) [start...end]
+//
+// The filter and the ordering and slice are optional. Filters may compare
+// attributes, literals, and parameters with `==`, `!=`, `<`, `<=`, `>`, `>=`,
+// and `in`, test attributes with `defined()`, and combine conditions with
+// `&&`, `||`, `!`, and parentheses. Projections and functions other than
+// `defined()` are not supported.
+type query struct {
+	filter expr
+	order  []ordering
+	slice  *slice
+}
+
+// An expr evaluates an expression against a document.
+type expr func(doc map[string]any) any
+
+type ordering struct {
+	path string
+	desc bool
+}
+
+type slice struct {
+	start, end int
+	single     bool
+}
+
+// parseQuery parses the source of a query, resolving the parameters it
+// references from `params`.
+func parseQuery(src string, params map[string]any) (*query, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, params: params}
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, fmt.Errorf("sanitytest: unsupported query %q: %w", src, err)
+	}
+
+	return q, nil
+}
+
+// eval returns the result of the query against the documents, which is either
+// an array of documents or, for a single-element slice, a document or nil.
+func (q *query) eval(docs []map[string]any) any {
+	matches := make([]map[string]any, 0, len(docs))
+	for _, doc := range docs {
+		if q.matches(doc) {
+			matches = append(matches, doc)
+		}
+	}
+
+	if len(q.order) > 0 {
+		sort.SliceStable(matches, func(i, j int) bool {
+			for _, o := range q.order {
+				c := compareValues(lookup(matches[i], o.path), lookup(matches[j], o.path))
+				if c != 0 {
+					return (c < 0) != o.desc
+				}
+			}
+			return false
+		})
+	}
+
+	if q.slice == nil {
+		return matches
+	}
+
+	start, end := q.slice.start, q.slice.end
+	if start < 0 {
+		start += len(matches)
+	}
+	if q.slice.single {
+		if start < 0 || start >= len(matches) {
+			return nil
+		}
+		return matches[start]
+	}
+	if end < 0 {
+		end += len(matches)
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > len(matches) {
+		end = len(matches)
+	}
+	if start >= end {
+		return []map[string]any{}
+	}
+
+	return matches[start:end]
+}
+
+// matches reports whether the document passes the filter of the query.
+func (q *query) matches(doc map[string]any) bool {
+	return q.filter == nil || q.filter(doc) == true
+}
+
+// lookup returns the value at the dotted path in the document, or nil if there
+// is none.
+func lookup(doc map[string]any, path string) any {
+	var value any = doc
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+
+	return value
+}
+
+// compareValues orders values of the same type. Values of different types are
+// ordered by type, with null last.
+func compareValues(a, b any) int {
+	rank := func(v any) int {
+		switch v.(type) {
+		case bool:
+			return 0
+		case float64:
+			return 1
+		case string:
+			return 2
+		case nil:
+			return 4
+		}
+		return 3
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+
+	switch a := a.(type) {
+	case bool:
+		switch {
+		case a == b.(bool):
+			return 0
+		case !a:
+			return -1
+		}
+		return 1
+	case float64:
+		switch b := b.(float64); {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	}
+
+	return 0
+}
+
+// -----------------------------------------------------------------------------
+// Parsing
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenParam
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+}
+
+// puncts are the punctuation tokens, longest first.
+var puncts = []string{"...", "..", "==", "!=", "<=", ">=", "&&", "||", "*", "[", "]", "(", ")", "|", ",", "<", ">", "!"}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	isIdentStart := func(c byte) bool {
+		return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+	}
+	isDigit := func(c byte) bool {
+		return c >= '0' && c <= '9'
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(src) && src[j] != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+					switch src[j] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(src[j])
+					}
+					continue
+				}
+				b.WriteByte(src[j])
+			}
+			if j == len(src) {
+				return nil, fmt.Errorf("sanitytest: unterminated string in query %q", src)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: b.String()})
+			i = j + 1
+		case isDigit(c) || (c == '-' && i+1 < len(src) && isDigit(src[i+1])):
+			j := i + 1
+			for j < len(src) && (isDigit(src[j]) || (src[j] == '.' && j+1 < len(src) && isDigit(src[j+1]))) {
+				j++
+			}
+			n, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[i:j], value: n})
+			i = j
+		case c == '$' || isIdentStart(c):
+			j := i + 1
+			for j < len(src) && (isIdentStart(src[j]) || isDigit(src[j]) || (src[j] == '.' && j+1 < len(src) && isIdentStart(src[j+1]))) {
+				j++
+			}
+			if c == '$' {
+				tokens = append(tokens, token{kind: tokenParam, text: src[i+1 : j]})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: src[i:j]})
+			}
+			i = j
+		default:
+			matched := false
+			for _, punct := range puncts {
+				if strings.HasPrefix(src[i:], punct) {
+					tokens = append(tokens, token{kind: tokenPunct, text: punct})
+					i += len(punct)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("sanitytest: unexpected character %q in query %q", c, src)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	params map[string]any
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the punctuation `punct`.
+func (p *parser) accept(punct string) bool {
+	if t := p.peek(); t.kind == tokenPunct && t.text == punct {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.accept(punct) {
+		return fmt.Errorf("expected %q", punct)
+	}
+	return nil
+}
+
+func (p *parser) parseQuery() (*query, error) {
+	if err := p.expect("*"); err != nil {
+		return nil, err
+	}
+
+	q := &query{}
+	if p.peek().text == "[" && !p.atSlice() {
+		p.next()
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		q.filter = filter
+	}
+
+	for p.accept("|") {
+		if t := p.next(); t.kind != tokenIdent || t.text != "order" {
+			return nil, fmt.Errorf("unsupported pipe")
+		}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		for {
+			t := p.next()
+			if t.kind != tokenIdent {
+				return nil, fmt.Errorf("expected attribute in order")
+			}
+			o := ordering{path: t.text}
+			if d := p.peek(); d.kind == tokenIdent && (d.text == "asc" || d.text == "desc") {
+				o.desc = p.next().text == "desc"
+			}
+			q.order = append(q.order, o)
+			if !p.accept(",") {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.accept("[") {
+		s, err := p.parseSlice()
+		if err != nil {
+			return nil, err
+		}
+		q.slice = s
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected trailing input")
+	}
+
+	return q, nil
+}
+
+// atSlice reports whether the brackets at the current position are a slice
+// rather than a filter.
+func (p *parser) atSlice() bool {
+	if p.tokens[p.pos+1].kind != tokenNumber {
+		return false
+	}
+	switch t := p.tokens[p.pos+2]; t.text {
+	case "]", "..", "...":
+		return t.kind == tokenPunct
+	}
+	return false
+}
+
+func (p *parser) parseSlice() (*slice, error) {
+	start, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("]") {
+		return &slice{start: start, single: true}, nil
+	}
+
+	inclusive := p.accept("..")
+	if !inclusive {
+		if err := p.expect("..."); err != nil {
+			return nil, err
+		}
+	}
+	end, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	if inclusive {
+		end++
+	}
+
+	return &slice{start: start, end: end}, p.expect("]")
+}
+
+func (p *parser) parseInt() (int, error) {
+	t := p.next()
+	if t.kind != tokenNumber {
+		return 0, fmt.Errorf("expected number in slice")
+	}
+	return strconv.Atoi(t.text)
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(doc map[string]any) any {
+			return l(doc) == true || right(doc) == true
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(doc map[string]any) any {
+			return l(doc) == true && right(doc) == true
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.accept("!") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(doc map[string]any) any {
+			return operand(doc) != true
+		}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	isIn := op.kind == tokenIdent && op.text == "in"
+	if op.kind != tokenPunct && !isIn {
+		return left, nil
+	}
+	switch op.text {
+	case "==", "!=", "<", "<=", ">", ">=", "in":
+		p.next()
+	default:
+		return left, nil
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(doc map[string]any) any {
+		a, b := left(doc), right(doc)
+		switch op.text {
+		case "==":
+			return reflect.DeepEqual(a, b)
+		case "!=":
+			return !reflect.DeepEqual(a, b)
+		case "in":
+			items, _ := b.([]any)
+			for _, item := range items {
+				if reflect.DeepEqual(a, item) {
+					return true
+				}
+			}
+			return false
+		}
+
+		// Ordering comparisons are only defined for numbers and strings of the
+		// same type.
+		_, aNum := a.(float64)
+		_, bNum := b.(float64)
+		_, aStr := a.(string)
+		_, bStr := b.(string)
+		if !(aNum && bNum) && !(aStr && bStr) {
+			return nil
+		}
+		c := compareValues(a, b)
+		switch op.text {
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		}
+		return c >= 0
+	}, nil
+}
+
+func (p *parser) parseOperand() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString, tokenNumber:
+		value := t.value
+		return func(map[string]any) any { return value }, nil
+	case tokenParam:
+		value, ok := p.params[t.text]
+		if !ok {
+			return nil, fmt.Errorf("param $%s referenced, but not provided", t.text)
+		}
+		return func(map[string]any) any { return value }, nil
+	case tokenIdent:
+		switch t.text {
+		case "true", "false":
+			value := t.text == "true"
+			return func(map[string]any) any { return value }, nil
+		case "null":
+			return func(map[string]any) any { return nil }, nil
+		case "defined":
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			attr := p.next()
+			if attr.kind != tokenIdent {
+				return nil, fmt.Errorf("expected attribute in defined()")
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return func(doc map[string]any) any { return lookup(doc, attr.text) != nil }, nil
+		}
+		path := t.text
+		return func(doc map[string]any) any { return lookup(doc, path) }, nil
+	case tokenPunct:
+		switch t.text {
+		case "(":
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expect(")")
+		case "[":
+			var items []expr
+			for !p.accept("]") {
+				if len(items) > 0 {
+					if err := p.expect(","); err != nil {
+						return nil, err
+					}
+				}
+				item, err := p.parseOperand()
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			return func(doc map[string]any) any {
+				values := make([]any, len(items))
+				for i, item := range items {
+					values[i] = item(doc)
+				}
+				return values
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected token %q", t.text)
+}
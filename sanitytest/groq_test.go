@@ -0,0 +1,84 @@
+package sanitytest
+
+import (
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	docs := []map[string]any{
+		{"_id": "a", "_type": "movie", "year": 1979.0, "slug": map[string]any{"current": "alien"}},
+		{"_id": "b", "_type": "movie", "year": 1995.0},
+		{"_id": "c", "_type": "person", "name": "Ridley"},
+	}
+
+	tests := []struct {
+		query  string
+		params map[string]any
+		ids    []string
+	}{
+		{`*`, nil, []string{"a", "b", "c"}},
+		{`*[_type == "movie"]`, nil, []string{"a", "b"}},
+		{`*[_type != 'movie']`, nil, []string{"c"}},
+		{`*[_type == $type && year >= 1980]`, map[string]any{"type": "movie"}, []string{"b"}},
+		{`*[_id in ["a", "c"]]`, nil, []string{"a", "c"}},
+		{`*[_id in $ids]`, map[string]any{"ids": []any{"b"}}, []string{"b"}},
+		{`*[defined(slug.current)]`, nil, []string{"a"}},
+		{`*[slug.current == "alien" || name == "Ridley"]`, nil, []string{"a", "c"}},
+		{`*[!(_type == "movie")]`, nil, []string{"c"}},
+		{`*[_type == "movie"] | order(year desc)`, nil, []string{"b", "a"}},
+		{`*[0...2]`, nil, []string{"a", "b"}},
+		{`* | order(_id desc) [0..1]`, nil, []string{"c", "b"}},
+	}
+
+	for _, tt := range tests {
+		q, err := parseQuery(tt.query, tt.params)
+		if err != nil {
+			t.Errorf("%s: Expected no error, got %v", tt.query, err)
+			continue
+		}
+
+		result := q.eval(docs).([]map[string]any)
+		var ids []string
+		for _, doc := range result {
+			ids = append(ids, doc["_id"].(string))
+		}
+		if len(ids) != len(tt.ids) {
+			t.Errorf("%s: Expected %v, got %v", tt.query, tt.ids, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.ids[i] {
+				t.Errorf("%s: Expected %v, got %v", tt.query, tt.ids, ids)
+				break
+			}
+		}
+	}
+}
+
+func TestParseQuery_Single(t *testing.T) {
+	q, err := parseQuery(`*[_type == "movie"][0]`, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	doc, ok := q.eval([]map[string]any{{"_id": "a", "_type": "movie"}}).(map[string]any)
+	if !ok || doc["_id"] != "a" {
+		t.Errorf("Expected document 'a', got %v", doc)
+	}
+	if result := q.eval(nil); result != nil {
+		t.Errorf("Expected nil, got %v", result)
+	}
+}
+
+func TestParseQuery_Unsupported(t *testing.T) {
+	for _, query := range []string{
+		`*[_type == "movie"]{title}`,
+		`count(*)`,
+		`*[_id in path("drafts.**")]`,
+		`*[_type == $missing]`,
+	} {
+		if _, err := parseQuery(query, nil); err == nil {
+			t.Errorf("%s: Expected an error", query)
+		}
+	}
+}
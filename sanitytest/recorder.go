@@ -0,0 +1,399 @@
+package sanitytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder sends requests to the API or replays
+// recorded responses.
+type RecorderMode int
+
+const (
+	// RecorderModeReplay serves requests from the fixture file, and fails
+	// requests that were not recorded. This is the default.
+	RecorderModeReplay RecorderMode = iota
+
+	// RecorderModeRecord sends requests to the API and records them, replacing
+	// the contents of the fixture file when the recorder is saved.
+	RecorderModeRecord
+)
+
+// redacted replaces redacted values in fixtures.
+const redacted = "[REDACTED]"
+
+// defaultRedactHeaders are the headers that are always redacted.
+var defaultRedactHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// defaultRedactFields are the JSON attributes that are always redacted.
+var defaultRedactFields = []string{"key", "password", "secret", "token"}
+
+// RecorderOptions configures a Recorder.
+type RecorderOptions struct {
+	// Mode selects whether requests are recorded or replayed. Defaults to
+	// RecorderModeReplay.
+	Mode RecorderMode
+
+	// Transport sends the requests in RecorderModeRecord. Defaults to
+	// http.DefaultTransport. It is expected to provide authentication.
+	Transport http.RoundTripper
+
+	// RedactHeaders are the headers whose values are redacted, in addition to
+	// the `Authorization`, `Cookie`, and `Set-Cookie` headers.
+	RedactHeaders []string
+
+	// RedactFields are the JSON attributes whose values are redacted from the
+	// bodies of requests and responses, in addition to `key`, `password`,
+	// `secret`, and `token`.
+	RedactFields []string
+
+	// Secrets are values that are redacted wherever they appear in URLs,
+	// headers, and bodies, e.g., the API token.
+	Secrets []string
+}
+
+// Recorder is an HTTP transport that records interactions with the Sanity API
+// to a fixture file and replays them in tests. Secrets are redacted before
+// interactions are written, so fixtures can be committed.
+//
+// Record the fixture once against the API, then replay it in later runs:
+//
+//	mode := sanitytest.RecorderModeReplay
+//	if os.Getenv("SANITY_RECORD") != "" {
+//		mode = sanitytest.RecorderModeRecord
+//	}
+//	rec, err := sanitytest.NewRecorder("testdata/projects.json", &sanitytest.RecorderOptions{
+//		Mode:      mode,
+//		Transport: authenticatedTransport,
+//	})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	t.Cleanup(func() {
+//		if err := rec.Save(); err != nil {
+//			t.Error(err)
+//		}
+//	})
+//
+//	client := sanity.NewClient(rec.Client())
+//
+// Requests are matched to recorded interactions by method and URL, in the
+// order they were recorded. Where several interactions have the same method
+// and URL, one with the same body is preferred.
+type Recorder struct {
+	path    string
+	options RecorderOptions
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// An Interaction is a request and its response, as stored in a fixture file.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// A RecordedRequest is a request stored in a fixture file.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// A RecordedResponse is a response stored in a fixture file.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// NewRecorder creates a recorder for the fixture file at `path`. In
+// RecorderModeReplay, the fixture file must exist. The options may be nil.
+func NewRecorder(path string, options *RecorderOptions) (*Recorder, error) {
+	r := &Recorder{path: path}
+	if options != nil {
+		r.options = *options
+	}
+	if r.options.Transport == nil {
+		r.options.Transport = http.DefaultTransport
+	}
+
+	if r.options.Mode == RecorderModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var f fixture
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("sanitytest: invalid fixture %s: %w", path, err)
+		}
+		r.interactions = f.Interactions
+		r.used = make([]bool, len(f.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an HTTP client that sends its requests through the recorder,
+// for use with sanity.NewClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	recorded := RecordedRequest{
+		Method:  req.Method,
+		URL:     r.redactString(req.URL.String()),
+		Headers: r.redactHeaders(req.Header),
+		Body:    r.redactBody(body),
+	}
+
+	if r.options.Mode == RecorderModeRecord {
+		return r.record(req, body, recorded)
+	}
+
+	return r.replay(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, recorded RecordedRequest) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.options.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.redactHeaders(resp.Header),
+			Body:       r.redactBody(respBody),
+		},
+	})
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != recorded.Method || interaction.Request.URL != recorded.URL {
+			continue
+		}
+		if match < 0 {
+			match = i
+		}
+		if interaction.Request.Body == recorded.Body {
+			match = i
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("sanitytest: no recorded interaction for %s %s in %s", recorded.Method, recorded.URL, r.path)
+	}
+	r.used[match] = true
+
+	recordedResp := r.interactions[match].Response
+	header := recordedResp.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResp.StatusCode, http.StatusText(recordedResp.StatusCode)),
+		StatusCode:    recordedResp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recordedResp.Body)),
+		ContentLength: int64(len(recordedResp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded interactions to the fixture file in
+// RecorderModeRecord, creating its directory if needed. It does nothing in
+// RecorderModeReplay.
+func (r *Recorder) Save() error {
+	if r.options.Mode != RecorderModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	f := fixture{Interactions: r.interactions}
+	r.mu.Unlock()
+	if f.Interactions == nil {
+		f.Interactions = []Interaction{}
+	}
+
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// Unused returns an error listing the recorded interactions that were not
+// replayed, e.g., to detect stale fixtures. It returns nil if all the
+// interactions were replayed.
+func (r *Recorder) Unused() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []string
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction.Request.Method+" "+interaction.Request.URL)
+		}
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+
+	return errors.New("sanitytest: unused recorded interactions: " + strings.Join(unused, ", "))
+}
+
+// -----------------------------------------------------------------------------
+// Redaction
+
+// redactString replaces the secrets in the string.
+func (r *Recorder) redactString(s string) string {
+	for _, secret := range r.options.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+
+	return s
+}
+
+// redactHeaders returns a copy of the headers with the values of the redacted
+// headers and the secrets replaced.
+func (r *Recorder) redactHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	redactHeaders := append(append([]string{}, defaultRedactHeaders...), r.options.RedactHeaders...)
+	result := make(http.Header, len(h))
+	for key, values := range h {
+		redactAll := false
+		for _, name := range redactHeaders {
+			if strings.EqualFold(key, name) {
+				redactAll = true
+			}
+		}
+
+		for _, value := range values {
+			if redactAll {
+				value = redacted
+			}
+			result[key] = append(result[key], r.redactString(value))
+		}
+	}
+
+	return result
+}
+
+// redactBody returns the body with the values of the redacted JSON attributes
+// and the secrets replaced. Bodies that are not JSON only have their secrets
+// replaced.
+func (r *Recorder) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		fields := append(append([]string{}, defaultRedactFields...), r.options.RedactFields...)
+		if redactFields(v, fields) {
+			if b, err := json.Marshal(v); err == nil {
+				body = b
+			}
+		}
+	}
+
+	return r.redactString(string(body))
+}
+
+// redactFields replaces the values of the attributes named `fields` in the
+// JSON value and reports whether any were replaced.
+func redactFields(v any, fields []string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			redact := false
+			for _, field := range fields {
+				if strings.EqualFold(key, field) {
+					redact = true
+				}
+			}
+			if redact && value != nil && value != "" {
+				v[key] = redacted
+				changed = true
+				continue
+			}
+			if redactFields(value, fields) {
+				changed = true
+			}
+		}
+	case []any:
+		for _, value := range v {
+			if redactFields(value, fields) {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
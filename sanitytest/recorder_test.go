@@ -0,0 +1,97 @@
+package sanitytest
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tessellator/go-sanity/sanity"
+)
+
+// authTransport adds a bearer token to the requests it sends.
+type authTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "testdata", "webhooks.json")
+
+	fake := NewServer()
+	fake.AddProject(sanity.Project{Id: "test-project"})
+
+	rec, err := NewRecorder(path, &RecorderOptions{
+		Mode:    RecorderModeRecord,
+		Secrets: []string{"sk-private"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client := sanity.NewClient(&http.Client{Transport: &authTransport{token: "sk-private", base: rec}})
+	rec.options.Transport = fake.Client().Transport
+
+	created, err := client.Webhooks.Create(ctx, "test-project", &sanity.CreateWebhookRequest{
+		Name:   "Notify",
+		URL:    "https://example.com/hook",
+		Secret: "webhook-signing-secret",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected fixture to be written, got %v", err)
+	}
+	for _, secret := range []string{"sk-private", "webhook-signing-secret"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("Expected %q to be redacted from the fixture", secret)
+		}
+	}
+
+	replay, err := NewRecorder(path, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client = sanity.NewClient(&http.Client{Transport: &authTransport{token: "sk-private", base: replay}})
+
+	webhook, err := client.Webhooks.Create(ctx, "test-project", &sanity.CreateWebhookRequest{
+		Name:   "Notify",
+		URL:    "https://example.com/hook",
+		Secret: "webhook-signing-secret",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if webhook.Id != created.Id || webhook.Secret != redacted {
+		t.Errorf("Expected the recorded webhook with a redacted secret, got %+v", webhook)
+	}
+	if err := replay.Unused(); err != nil {
+		t.Errorf("Expected all interactions to be replayed, got %v", err)
+	}
+
+	_, err = client.Webhooks.List(ctx, "test-project")
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("Expected an error for an unrecorded request, got %v", err)
+	}
+}
+
+func TestRecorder_MissingFixture(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), nil)
+	if err == nil {
+		t.Error("Expected an error for a missing fixture")
+	}
+}
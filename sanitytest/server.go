@@ -0,0 +1,1126 @@
+/*
+Package sanitytest provides an in-memory fake of the Sanity HTTP API for
+testing code that uses the sanity package, without recording HTTP fixtures.
+
+The fake implements the Projects API for projects and datasets, the Webhooks
+API, and the Doc, Query, and Mutations APIs. Queries and the queries of
+mutations are limited to a subset of GROQ; see Server for details.
+
+	fake := sanitytest.NewServer()
+	fake.AddDocuments("my-project", "production", sanity.Document{
+		"_id":   "movie-1",
+		"_type": "movie",
+		"title": "Alien",
+	})
+
+	client := sanity.NewClient(fake.Client())
+
+	var movies []sanity.Document
+	err := client.Documents.Query(ctx, "my-project", "production", &sanity.QueryRequest{
+		Query: `*[_type == "movie"]`,
+	}, &movies)
+*/
+package sanitytest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tessellator/go-sanity/sanity"
+)
+
+// Server is an in-memory fake of the Sanity HTTP API. It is safe for
+// concurrent use.
+//
+// Queries support the following subset of GROQ:
+//
+//	*[filter] | order(attribute asc, ...) [start...end]
+//
+// where filters compare attributes, literals, and parameters with `==`, `!=`,
+// `<`, `<=`, `>`, `>=`, and `in`, test attributes with `defined()`, and combine
+// conditions with `&&`, `||`, `!`, and parentheses. Other queries, and patches
+// with `insert` or `diffMatchPatch` operations, are rejected with a 400
+// response that names the unsupported feature.
+type Server struct {
+	mu       sync.Mutex
+	projects map[string]*project
+}
+
+type project struct {
+	sanity.Project
+	datasets map[string]*dataset
+	webhooks []*sanity.Webhook
+}
+
+type dataset struct {
+	sanity.Dataset
+	documents map[string]map[string]any
+}
+
+// NewServer returns an empty fake.
+func NewServer() *Server {
+	return &Server{projects: map[string]*project{}}
+}
+
+// Client returns an HTTP client that sends requests for the Sanity API to the
+// fake, for use with sanity.NewClient.
+func (s *Server) Client() *http.Client {
+	return &http.Client{Transport: roundTripperFunc(s.roundTrip)}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (s *Server) roundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	resp := rec.Result()
+	resp.Request = req
+
+	return resp, nil
+}
+
+// -----------------------------------------------------------------------------
+// Seeding and inspection
+
+// AddProject adds the project to the fake, replacing any project with the same
+// identifier, and returns its identifier. An identifier is generated if the
+// project has none.
+func (s *Server) AddProject(p sanity.Project) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addProject(p).Id
+}
+
+func (s *Server) addProject(p sanity.Project) *project {
+	if p.Id == "" {
+		p.Id = newId(8)
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = now()
+	}
+	if p.Metadata == nil {
+		p.Metadata = map[string]string{}
+	}
+	if p.Members == nil {
+		p.Members = []sanity.Member{}
+	}
+
+	proj := &project{Project: p, datasets: map[string]*dataset{}}
+	s.projects[p.Id] = proj
+
+	return proj
+}
+
+// project returns the project with the identifier, adding it if it does not
+// exist.
+func (s *Server) project(projectId string) *project {
+	if proj, ok := s.projects[projectId]; ok {
+		return proj
+	}
+
+	return s.addProject(sanity.Project{Id: projectId, DisplayName: projectId})
+}
+
+// AddDataset adds the dataset to the project, adding the project if it does
+// not exist. Any existing dataset with the same name is replaced.
+func (s *Server) AddDataset(projectId string, d sanity.Dataset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addDataset(s.project(projectId), d)
+}
+
+func (s *Server) addDataset(proj *project, d sanity.Dataset) *dataset {
+	if d.AclMode == "" {
+		d.AclMode = sanity.AclModePublic
+	}
+
+	ds := &dataset{Dataset: d, documents: map[string]map[string]any{}}
+	proj.datasets[d.Name] = ds
+
+	return ds
+}
+
+// AddDocuments adds the documents to the dataset, adding the project and the
+// dataset if they do not exist. Documents with the same identifiers are
+// replaced. Identifiers and system attributes are generated for documents
+// that lack them.
+func (s *Server) AddDocuments(projectId, datasetName string, docs ...sanity.Document) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proj := s.project(projectId)
+	ds, ok := proj.datasets[datasetName]
+	if !ok {
+		ds = s.addDataset(proj, sanity.Dataset{Name: datasetName})
+	}
+
+	rev := newId(22)
+	for _, doc := range docs {
+		d := cloneDocument(doc)
+		if _, ok := d["_id"].(string); !ok {
+			d["_id"] = newId(22)
+		}
+		stamp(d, nil, rev)
+		ds.documents[d["_id"].(string)] = d
+	}
+}
+
+// Document returns the document with the identifier in the dataset, or nil if
+// it does not exist.
+func (s *Server) Document(projectId, datasetName, docId string) sanity.Document {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ds := s.dataset(projectId, datasetName)
+	if ds == nil || ds.documents[docId] == nil {
+		return nil
+	}
+
+	return cloneDocument(ds.documents[docId])
+}
+
+// Documents returns all the documents in the dataset, including drafts, ordered
+// by identifier.
+func (s *Server) Documents(projectId, datasetName string) []sanity.Document {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ds := s.dataset(projectId, datasetName)
+	if ds == nil {
+		return nil
+	}
+
+	var docs []sanity.Document
+	for _, doc := range ds.sortedDocuments() {
+		docs = append(docs, cloneDocument(doc))
+	}
+
+	return docs
+}
+
+// dataset returns the dataset, or nil if it or its project does not exist.
+func (s *Server) dataset(projectId, datasetName string) *dataset {
+	proj, ok := s.projects[projectId]
+	if !ok {
+		return nil
+	}
+
+	return proj.datasets[datasetName]
+}
+
+// AddWebhook adds the webhook to the project, adding the project if it does
+// not exist, and returns its identifier. An identifier is generated if the
+// webhook has none.
+func (s *Server) AddWebhook(projectId string, w sanity.Webhook) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proj := s.project(projectId)
+	if w.Id == "" {
+		w.Id = newId(16)
+	}
+	w.ProjectId = projectId
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = now()
+		w.UpdatedAt = w.CreatedAt
+	}
+	proj.webhooks = append(proj.webhooks, &w)
+
+	return w.Id
+}
+
+// Webhooks returns the webhooks of the project in the order they were created.
+func (s *Server) Webhooks(projectId string) []sanity.Webhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proj, ok := s.projects[projectId]
+	if !ok {
+		return nil
+	}
+
+	webhooks := make([]sanity.Webhook, 0, len(proj.webhooks))
+	for _, w := range proj.webhooks {
+		webhooks = append(webhooks, *w)
+	}
+
+	return webhooks
+}
+
+// -----------------------------------------------------------------------------
+// Routing
+
+// ServeHTTP serves a request for the Sanity API. Requests for a host of the
+// form `<projectId>.api.sanity.io` are served by the project-scoped APIs, and
+// all other requests by the management APIs.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) > 0 && isAPIVersion(segments[0]) {
+		segments = segments[1:]
+	}
+
+	host := r.Host
+	if host == "" {
+		host = r.URL.Host
+	}
+	if strings.HasSuffix(host, ".api.sanity.io") {
+		s.serveProject(w, r, strings.TrimSuffix(host, ".api.sanity.io"), segments)
+		return
+	}
+
+	s.serveManagement(w, r, segments)
+}
+
+// isAPIVersion reports whether the path segment is an API version, e.g.,
+// `v2021-06-07` or `v1`.
+func isAPIVersion(segment string) bool {
+	return len(segment) > 1 && segment[0] == 'v' && segment[1] >= '0' && segment[1] <= '9'
+}
+
+// match reports whether the path segments match the pattern, where `*`
+// matches any segment, and returns the segments matched by `*`.
+func match(segments []string, pattern ...string) ([]string, bool) {
+	if len(segments) != len(pattern) {
+		return nil, false
+	}
+
+	var vars []string
+	for i, p := range pattern {
+		switch {
+		case p == "*":
+			vars = append(vars, segments[i])
+		case p != segments[i]:
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
+
+func (s *Server) serveManagement(w http.ResponseWriter, r *http.Request, segments []string) {
+	if _, ok := match(segments, "projects"); ok {
+		switch r.Method {
+		case http.MethodGet:
+			s.listProjects(w)
+			return
+		case http.MethodPost:
+			s.createProject(w, r)
+			return
+		}
+	}
+
+	if vars, ok := match(segments, "projects", "*"); ok {
+		proj, ok := s.projects[vars[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Project %q not found", vars[0]))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, proj.Project)
+			return
+		case http.MethodPatch:
+			s.updateProject(w, r, proj)
+			return
+		case http.MethodDelete:
+			delete(s.projects, proj.Id)
+			writeJSON(w, http.StatusOK, map[string]any{"deleted": true})
+			return
+		}
+	}
+
+	if vars, ok := match(segments, "projects", "*", "datasets"); ok && r.Method == http.MethodGet {
+		proj, ok := s.projects[vars[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Project %q not found", vars[0]))
+			return
+		}
+		s.listDatasets(w, proj)
+		return
+	}
+
+	if vars, ok := match(segments, "projects", "*", "datasets", "*"); ok {
+		proj, ok := s.projects[vars[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Project %q not found", vars[0]))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodPatch, http.MethodDelete:
+			ds, ok := proj.datasets[vars[1]]
+			if !ok {
+				writeError(w, http.StatusNotFound, fmt.Sprintf("Dataset %q not found", vars[1]))
+				return
+			}
+			s.serveDataset(w, r, proj, ds)
+			return
+		case http.MethodPut:
+			s.createDataset(w, r, proj, vars[1])
+			return
+		}
+	}
+
+	writeUnsupported(w, r)
+}
+
+func (s *Server) serveProject(w http.ResponseWriter, r *http.Request, projectId string, segments []string) {
+	proj, ok := s.projects[projectId]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Project %q not found", projectId))
+		return
+	}
+
+	if len(segments) >= 3 && segments[0] == "data" {
+		ds, ok := proj.datasets[segments[2]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Dataset %q not found", segments[2]))
+			return
+		}
+
+		switch {
+		case segments[1] == "doc" && len(segments) == 4 && r.Method == http.MethodGet:
+			s.getDocuments(w, ds, strings.Split(segments[3], ","))
+			return
+		case segments[1] == "query" && len(segments) == 3 && (r.Method == http.MethodGet || r.Method == http.MethodPost):
+			s.query(w, r, ds)
+			return
+		case segments[1] == "mutate" && len(segments) == 3 && r.Method == http.MethodPost:
+			s.mutate(w, r, ds)
+			return
+		}
+	}
+
+	if vars, ok := match(segments, "hooks", "projects", "*"); ok && vars[0] == projectId {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, s.webhooks(proj))
+			return
+		case http.MethodPost:
+			s.createWebhook(w, r, proj)
+			return
+		}
+	}
+
+	if vars, ok := match(segments, "hooks", "projects", "*", "*"); ok && vars[0] == projectId {
+		index := -1
+		for i, webhook := range proj.webhooks {
+			if webhook.Id == vars[1] {
+				index = i
+			}
+		}
+		if index < 0 {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Webhook %q not found", vars[1]))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, proj.webhooks[index])
+			return
+		case http.MethodPatch:
+			s.updateWebhook(w, r, proj.webhooks[index])
+			return
+		case http.MethodDelete:
+			proj.webhooks = append(proj.webhooks[:index], proj.webhooks[index+1:]...)
+			writeJSON(w, http.StatusOK, map[string]any{"deleted": true})
+			return
+		}
+	}
+
+	writeUnsupported(w, r)
+}
+
+// -----------------------------------------------------------------------------
+// Projects and datasets
+
+func (s *Server) listProjects(w http.ResponseWriter) {
+	projects := make([]sanity.Project, 0, len(s.projects))
+	for _, proj := range s.projects {
+		projects = append(projects, proj.Project)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Id < projects[j].Id
+	})
+
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var req sanity.CreateProjectRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.DisplayName == "" {
+		writeError(w, http.StatusBadRequest, "displayName is required")
+		return
+	}
+
+	proj := s.addProject(sanity.Project{
+		DisplayName:         req.DisplayName,
+		OrganizationId:      req.OrganizationId,
+		ActivityFeedEnabled: true,
+	})
+
+	writeJSON(w, http.StatusOK, proj.Project)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, proj *project) {
+	var req struct {
+		DisplayName         *string            `json:"displayName"`
+		StudioHost          *string            `json:"studioHost"`
+		Metadata            map[string]*string `json:"metadata"`
+		IsDisabledByUser    *bool              `json:"isDisabledByUser"`
+		ActivityFeedEnabled *bool              `json:"activityFeedEnabled"`
+		OrganizationId      json.RawMessage    `json:"organizationId"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	if req.DisplayName != nil {
+		proj.DisplayName = *req.DisplayName
+	}
+	if req.StudioHost != nil {
+		if proj.StudioHost != "" && proj.StudioHost != *req.StudioHost {
+			writeError(w, http.StatusBadRequest, "studioHost cannot be changed once set")
+			return
+		}
+		proj.StudioHost = *req.StudioHost
+	}
+	for key, value := range req.Metadata {
+		if value == nil {
+			delete(proj.Metadata, key)
+		} else {
+			proj.Metadata[key] = *value
+		}
+	}
+	if req.IsDisabledByUser != nil {
+		proj.IsDisabledByUser = *req.IsDisabledByUser
+	}
+	if req.ActivityFeedEnabled != nil {
+		proj.ActivityFeedEnabled = *req.ActivityFeedEnabled
+	}
+	if req.OrganizationId != nil {
+		var organizationId *string
+		json.Unmarshal(req.OrganizationId, &organizationId)
+		proj.OrganizationId = ""
+		if organizationId != nil {
+			proj.OrganizationId = *organizationId
+		}
+	}
+
+	writeJSON(w, http.StatusOK, proj.Project)
+}
+
+func (s *Server) listDatasets(w http.ResponseWriter, proj *project) {
+	datasets := make([]sanity.Dataset, 0, len(proj.datasets))
+	for _, ds := range proj.datasets {
+		datasets = append(datasets, ds.Dataset)
+	}
+	sort.Slice(datasets, func(i, j int) bool {
+		return datasets[i].Name < datasets[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, datasets)
+}
+
+func (s *Server) createDataset(w http.ResponseWriter, r *http.Request, proj *project, name string) {
+	var req sanity.CreateDatasetRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if err := sanity.ValidateDatasetName(name); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, ok := proj.datasets[name]; ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Dataset %q already exists", name))
+		return
+	}
+
+	ds := s.addDataset(proj, sanity.Dataset{Name: name, AclMode: req.AclMode})
+	writeJSON(w, http.StatusOK, map[string]any{"datasetName": ds.Name, "aclMode": ds.AclMode})
+}
+
+func (s *Server) serveDataset(w http.ResponseWriter, r *http.Request, proj *project, ds *dataset) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, ds.Dataset)
+	case http.MethodPatch:
+		var req sanity.EditDatasetRequest
+		if !decodeBody(w, r, &req) {
+			return
+		}
+		if req.AclMode != "" {
+			ds.AclMode = req.AclMode
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"datasetName": ds.Name, "aclMode": ds.AclMode})
+	case http.MethodDelete:
+		delete(proj.datasets, ds.Name)
+		writeJSON(w, http.StatusOK, map[string]any{"deleted": true})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Webhooks
+
+func (s *Server) webhooks(proj *project) []*sanity.Webhook {
+	webhooks := make([]*sanity.Webhook, len(proj.webhooks))
+	copy(webhooks, proj.webhooks)
+
+	return webhooks
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request, proj *project) {
+	var req sanity.CreateWebhookRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.URL == "" {
+		writeError(w, http.StatusBadRequest, "name and url are required")
+		return
+	}
+
+	t := now()
+	webhook := &sanity.Webhook{
+		Id:          newId(16),
+		ProjectId:   proj.Id,
+		Type:        req.Type,
+		Name:        req.Name,
+		Description: req.Description,
+		Dataset:     req.Dataset,
+		URL:         req.URL,
+		HttpMethod:  req.HttpMethod,
+		ApiVersion:  req.ApiVersion,
+		Headers:     req.Headers,
+		Rule:        req.Rule,
+		Secret:      req.Secret,
+		CreatedAt:   t,
+		UpdatedAt:   t,
+	}
+	if webhook.Type == "" {
+		webhook.Type = "document"
+	}
+	if webhook.HttpMethod == "" {
+		webhook.HttpMethod = http.MethodPost
+	}
+	if req.IncludeDrafts != nil {
+		webhook.IncludeDrafts = *req.IncludeDrafts
+	}
+	if req.IncludeVersions != nil {
+		webhook.IncludeVersions = *req.IncludeVersions
+	}
+	if req.IsDisabledByUser != nil {
+		webhook.IsDisabledByUser = *req.IsDisabledByUser
+	}
+	proj.webhooks = append(proj.webhooks, webhook)
+
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request, webhook *sanity.Webhook) {
+	var req sanity.UpdateWebhookRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	setString := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	setBool := func(dst *bool, src *bool) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setString(&webhook.Type, req.Type)
+	setString(&webhook.Name, req.Name)
+	setString(&webhook.Description, req.Description)
+	setString(&webhook.URL, req.URL)
+	setString(&webhook.HttpMethod, req.HttpMethod)
+	setString(&webhook.ApiVersion, req.ApiVersion)
+	setString(&webhook.Secret, req.Secret)
+	setBool(&webhook.IncludeDrafts, req.IncludeDrafts)
+	setBool(&webhook.IncludeVersions, req.IncludeVersions)
+	setBool(&webhook.IsDisabledByUser, req.IsDisabledByUser)
+	if req.Headers != nil {
+		webhook.Headers = req.Headers
+	}
+	if req.Rule != nil {
+		webhook.Rule = req.Rule
+	}
+	webhook.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+// -----------------------------------------------------------------------------
+// Documents
+
+func (s *Server) getDocuments(w http.ResponseWriter, ds *dataset, ids []string) {
+	docs := []map[string]any{}
+	omitted := []map[string]any{}
+	for _, id := range ids {
+		if doc, ok := ds.documents[id]; ok {
+			docs = append(docs, doc)
+		} else {
+			omitted = append(omitted, map[string]any{"id": id, "reason": "existence"})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"documents": docs, "omitted": omitted})
+}
+
+func (s *Server) query(w http.ResponseWriter, r *http.Request, ds *dataset) {
+	var req struct {
+		Query  string         `json:"query"`
+		Params map[string]any `json:"params"`
+	}
+	if r.Method == http.MethodPost {
+		if !decodeBody(w, r, &req) {
+			return
+		}
+	} else {
+		req.Query = r.URL.Query().Get("query")
+		req.Params = map[string]any{}
+		for key, values := range r.URL.Query() {
+			if !strings.HasPrefix(key, "$") {
+				continue
+			}
+			var value any
+			if err := json.Unmarshal([]byte(values[0]), &value); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid value for param %s", key))
+				return
+			}
+			req.Params[strings.TrimPrefix(key, "$")] = value
+		}
+	}
+
+	q, err := parseQuery(req.Query, req.Params)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	docs, err := ds.perspective(r.URL.Query().Get("perspective"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"ms": 0, "query": req.Query, "result": q.eval(docs)})
+}
+
+// sortedDocuments returns the documents of the dataset ordered by identifier.
+func (ds *dataset) sortedDocuments() []map[string]any {
+	docs := make([]map[string]any, 0, len(ds.documents))
+	for _, doc := range ds.documents {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i]["_id"].(string) < docs[j]["_id"].(string)
+	})
+
+	return docs
+}
+
+// perspective returns the documents of the dataset as seen by queries with the
+// perspective.
+func (ds *dataset) perspective(perspective string) ([]map[string]any, error) {
+	docs := ds.sortedDocuments()
+	switch perspective {
+	case sanity.PerspectiveRaw:
+		return docs, nil
+	case "", sanity.PerspectivePublished:
+		published := make([]map[string]any, 0, len(docs))
+		for _, doc := range docs {
+			if !isDraftOrVersion(doc["_id"].(string)) {
+				published = append(published, doc)
+			}
+		}
+		return published, nil
+	case sanity.PerspectiveDrafts:
+		var result []map[string]any
+		for _, doc := range docs {
+			id := doc["_id"].(string)
+			if strings.HasPrefix(id, "drafts.") {
+				overlay := cloneDocument(doc)
+				overlay["_id"] = strings.TrimPrefix(id, "drafts.")
+				overlay["_originalId"] = id
+				result = append(result, overlay)
+				continue
+			}
+			if isDraftOrVersion(id) {
+				continue
+			}
+			if _, ok := ds.documents["drafts."+id]; !ok {
+				result = append(result, doc)
+			}
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i]["_id"].(string) < result[j]["_id"].(string)
+		})
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("sanitytest: unsupported perspective %q", perspective)
+}
+
+func isDraftOrVersion(id string) bool {
+	return strings.HasPrefix(id, "drafts.") || strings.HasPrefix(id, "versions.")
+}
+
+// A mutationError is an error that fails a transaction.
+type mutationError struct {
+	status  int
+	message string
+}
+
+func (e *mutationError) Error() string {
+	return e.message
+}
+
+func (s *Server) mutate(w http.ResponseWriter, r *http.Request, ds *dataset) {
+	var req sanity.MutateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	transactionId := req.TransactionId
+	if transactionId == "" {
+		transactionId = newId(22)
+	}
+
+	// The mutations are applied to a copy of the documents, which replaces the
+	// documents once all the mutations succeed.
+	docs := make(map[string]map[string]any, len(ds.documents))
+	for id, doc := range ds.documents {
+		docs[id] = doc
+	}
+
+	var results []map[string]any
+	for _, m := range req.Mutations {
+		ids, operation, err := applyMutation(docs, m, transactionId)
+		if err != nil {
+			status := http.StatusBadRequest
+			if merr, ok := err.(*mutationError); ok {
+				status = merr.status
+			}
+			writeJSON(w, status, map[string]any{
+				"error": map[string]any{"type": "mutationError", "description": err.Error()},
+			})
+			return
+		}
+		for _, id := range ids {
+			results = append(results, map[string]any{"id": id, "operation": operation})
+		}
+	}
+	ds.documents = docs
+
+	returnIds := r.URL.Query().Get("returnIds") != "false"
+	returnDocuments := r.URL.Query().Get("returnDocuments") == "true"
+	for _, result := range results {
+		if returnDocuments && result["operation"] != "delete" {
+			result["document"] = docs[result["id"].(string)]
+		}
+		if !returnIds {
+			delete(result, "id")
+		}
+	}
+	if results == nil {
+		results = []map[string]any{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"transactionId": transactionId, "results": results})
+}
+
+// applyMutation applies the mutation to the documents and returns the
+// identifiers of the affected documents and the operation applied to them.
+func applyMutation(docs map[string]map[string]any, m sanity.Mutation, rev string) ([]string, string, error) {
+	create := func(v any, replace, skipExisting bool) ([]string, string, error) {
+		doc, ok := v.(map[string]any)
+		if !ok {
+			return nil, "", fmt.Errorf("document must be an object")
+		}
+		id, _ := doc["_id"].(string)
+		if id == "" {
+			id = newId(22)
+			doc["_id"] = id
+		} else if strings.HasSuffix(id, ".") {
+			id += newId(22)
+			doc["_id"] = id
+		}
+		if _, ok := doc["_type"].(string); !ok {
+			return nil, "", fmt.Errorf("document %q is missing _type", id)
+		}
+
+		existing, exists := docs[id]
+		switch {
+		case exists && skipExisting:
+			return []string{id}, "none", nil
+		case exists && !replace:
+			return nil, "", &mutationError{http.StatusConflict, fmt.Sprintf("Document by ID %q already exists", id)}
+		}
+
+		stamp(doc, existing, rev)
+		docs[id] = doc
+		if exists {
+			return []string{id}, "update", nil
+		}
+		return []string{id}, "create", nil
+	}
+
+	switch {
+	case m.Create != nil:
+		return create(m.Create, false, false)
+	case m.CreateOrReplace != nil:
+		return create(m.CreateOrReplace, true, false)
+	case m.CreateIfNotExists != nil:
+		return create(m.CreateIfNotExists, false, true)
+	case m.Delete != nil:
+		ids, err := selectDocuments(docs, m.Delete.Id, m.Delete.Query, m.Delete.Params)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, id := range ids {
+			delete(docs, id)
+		}
+		return ids, "delete", nil
+	case m.Patch != nil:
+		ids, err := selectDocuments(docs, m.Patch.Id, m.Patch.Query, m.Patch.Params)
+		if err != nil {
+			return nil, "", err
+		}
+		if m.Patch.Id != "" && len(ids) == 0 {
+			return nil, "", &mutationError{http.StatusNotFound, fmt.Sprintf("Document by ID %q not found", m.Patch.Id)}
+		}
+		for _, id := range ids {
+			doc, err := applyPatch(docs[id], m.Patch)
+			if err != nil {
+				return nil, "", err
+			}
+			stamp(doc, docs[id], rev)
+			docs[id] = doc
+		}
+		return ids, "update", nil
+	}
+
+	return nil, "", fmt.Errorf("mutation has no operation")
+}
+
+// selectDocuments returns the identifiers of the existing documents that are
+// selected either by identifier or by query.
+func selectDocuments(docs map[string]map[string]any, id, src string, params map[string]any) ([]string, error) {
+	if id != "" {
+		if _, ok := docs[id]; !ok {
+			return nil, nil
+		}
+		return []string{id}, nil
+	}
+
+	q, err := parseQuery(src, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for docId, doc := range docs {
+		if q.matches(doc) {
+			ids = append(ids, docId)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// applyPatch returns a copy of the document with the patch applied.
+func applyPatch(doc map[string]any, patch *sanity.Patch) (map[string]any, error) {
+	if patch.Insert != nil || patch.DiffMatchPatch != nil {
+		return nil, fmt.Errorf("sanitytest: insert and diffMatchPatch patches are not supported")
+	}
+	if patch.IfRevisionId != "" && doc["_rev"] != patch.IfRevisionId {
+		return nil, &mutationError{http.StatusConflict, fmt.Sprintf("Document by ID %q has unexpected revision ID (%q), expected %q", doc["_id"], doc["_rev"], patch.IfRevisionId)}
+	}
+
+	doc = cloneDocument(doc)
+	for path, value := range patch.Set {
+		if err := setPath(doc, path, value); err != nil {
+			return nil, err
+		}
+	}
+	for path, value := range patch.SetIfMissing {
+		if lookup(doc, path) != nil {
+			continue
+		}
+		if err := setPath(doc, path, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range patch.Unset {
+		if err := unsetPath(doc, path); err != nil {
+			return nil, err
+		}
+	}
+	for path, delta := range patch.Inc {
+		if n, ok := lookup(doc, path).(float64); ok {
+			setPath(doc, path, n+delta)
+		}
+	}
+	for path, delta := range patch.Dec {
+		if n, ok := lookup(doc, path).(float64); ok {
+			setPath(doc, path, n-delta)
+		}
+	}
+
+	return doc, nil
+}
+
+// setPath sets the value at the dotted path in the document, creating objects
+// along the path as needed.
+func setPath(doc map[string]any, path string, value any) error {
+	if strings.ContainsAny(path, "[]") {
+		return fmt.Errorf("sanitytest: array paths are not supported in patches: %s", path)
+	}
+
+	keys := strings.Split(path, ".")
+	m := doc
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[key] = next
+		}
+		m = next
+	}
+	m[keys[len(keys)-1]] = value
+
+	return nil
+}
+
+// unsetPath removes the value at the dotted path in the document.
+func unsetPath(doc map[string]any, path string) error {
+	if strings.ContainsAny(path, "[]") {
+		return fmt.Errorf("sanitytest: array paths are not supported in patches: %s", path)
+	}
+
+	keys := strings.Split(path, ".")
+	m := doc
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	delete(m, keys[len(keys)-1])
+
+	return nil
+}
+
+// stamp sets the system attributes of a document that is written with the
+// revision `rev`, replacing the document `existing`, if any.
+func stamp(doc, existing map[string]any, rev string) {
+	t := now().Format(time.RFC3339)
+	doc["_rev"] = rev
+	doc["_updatedAt"] = t
+	if existing != nil {
+		doc["_createdAt"] = existing["_createdAt"]
+	} else if _, ok := doc["_createdAt"].(string); !ok {
+		doc["_createdAt"] = t
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Helpers
+
+// cloneDocument returns a deep copy of the document.
+func cloneDocument(doc map[string]any) map[string]any {
+	b, _ := json.Marshal(doc)
+
+	var clone map[string]any
+	json.Unmarshal(b, &clone)
+
+	return clone
+}
+
+// decodeBody decodes the JSON body of the request into `v`. It writes an error
+// response and returns false if the body is invalid.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.Body == nil {
+		return true
+	}
+
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format of the Sanity API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"statusCode": status,
+		"error":      http.StatusText(status),
+		"message":    message,
+	})
+}
+
+func writeUnsupported(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("sanitytest: unsupported endpoint %s %s", r.Method, r.URL.Path))
+}
+
+// now returns the current time, truncated to the precision of the API.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// newId returns a random identifier of `n` lowercase alphanumeric characters.
+func newId(n int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+
+	return string(b)
+}
@@ -0,0 +1,223 @@
+package sanitytest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/tessellator/go-sanity/sanity"
+)
+
+func TestServer_Projects(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	client := sanity.NewClient(fake.Client())
+
+	project, err := client.Projects.Create(ctx, &sanity.CreateProjectRequest{DisplayName: "Test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if project.Id == "" || project.DisplayName != "Test" {
+		t.Errorf("Expected created project, got %+v", project)
+	}
+
+	updated, err := client.Projects.Update(ctx, project.Id, &sanity.UpdateProjectRequest{DisplayName: "Renamed", Color: "#FF0000"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.DisplayName != "Renamed" || updated.Metadata["color"] != "#ff0000" {
+		t.Errorf("Expected updated project, got %+v", updated)
+	}
+
+	projects, err := client.Projects.List(ctx)
+	if err != nil || len(projects) != 1 {
+		t.Errorf("Expected 1 project, got %d (%v)", len(projects), err)
+	}
+
+	deleted, err := client.Projects.Delete(ctx, project.Id)
+	if err != nil || !deleted {
+		t.Errorf("Expected project to be deleted, got %v (%v)", deleted, err)
+	}
+
+	_, err = client.Projects.Get(ctx, project.Id)
+	var apiErr *sanity.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestServer_Datasets(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	fake.AddProject(sanity.Project{Id: "test-project"})
+	client := sanity.NewClient(fake.Client())
+
+	_, err := client.Projects.CreateDataset(ctx, "test-project", &sanity.CreateDatasetRequest{Name: "staging", AclMode: sanity.AclModePrivate})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = client.Projects.CreateDataset(ctx, "test-project", &sanity.CreateDatasetRequest{Name: "staging"})
+	var apiErr *sanity.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+
+	dataset, err := client.Projects.EditDataset(ctx, "test-project", "staging", &sanity.EditDatasetRequest{AclMode: sanity.AclModePublic})
+	if err != nil || dataset.AclMode != sanity.AclModePublic {
+		t.Errorf("Expected public dataset, got %+v (%v)", dataset, err)
+	}
+
+	datasets, err := client.Projects.ListDatasets(ctx, "test-project")
+	if err != nil || len(datasets) != 1 || datasets[0].Name != "staging" {
+		t.Errorf("Expected dataset 'staging', got %+v (%v)", datasets, err)
+	}
+}
+
+func TestServer_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	fake.AddProject(sanity.Project{Id: "test-project"})
+	client := sanity.NewClient(fake.Client())
+
+	webhook, err := client.Webhooks.Create(ctx, "test-project", &sanity.CreateWebhookRequest{
+		Name:    "Notify",
+		Dataset: "production",
+		URL:     "https://example.com/hook",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	disabled, err := client.Webhooks.Disable(ctx, "test-project", webhook.Id)
+	if err != nil || !disabled.IsDisabledByUser {
+		t.Errorf("Expected disabled webhook, got %+v (%v)", disabled, err)
+	}
+	if webhooks := fake.Webhooks("test-project"); len(webhooks) != 1 || !webhooks[0].IsDisabledByUser {
+		t.Errorf("Expected the fake to hold the disabled webhook, got %+v", webhooks)
+	}
+
+	deleted, err := client.Webhooks.Delete(ctx, "test-project", webhook.Id)
+	if err != nil || !deleted {
+		t.Errorf("Expected webhook to be deleted, got %v (%v)", deleted, err)
+	}
+	if webhooks, _ := client.Webhooks.List(ctx, "test-project"); len(webhooks) != 0 {
+		t.Errorf("Expected no webhooks, got %+v", webhooks)
+	}
+}
+
+func TestServer_Documents(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	fake.AddDocuments("test-project", "production",
+		sanity.Document{"_id": "movie-1", "_type": "movie", "title": "Alien", "year": 1979},
+		sanity.Document{"_id": "movie-2", "_type": "movie", "title": "Heat", "year": 1995},
+		sanity.Document{"_id": "drafts.movie-2", "_type": "movie", "title": "Heat (draft)", "year": 1995},
+		sanity.Document{"_id": "person-1", "_type": "person", "name": "Ridley"},
+	)
+	client := sanity.NewClient(fake.Client())
+
+	var movies []sanity.Document
+	err := client.Documents.Query(ctx, "test-project", "production", &sanity.QueryRequest{
+		Query:  `*[_type == $type && year > 1980] | order(year desc)`,
+		Params: map[string]any{"type": "movie"},
+	}, &movies)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(movies) != 1 || movies[0].Id() != "movie-2" {
+		t.Errorf("Expected published movie-2, got %v", movies)
+	}
+
+	err = client.Documents.Query(ctx, "test-project", "production", &sanity.QueryRequest{
+		Query:       `*[_type == "movie"]`,
+		Perspective: sanity.PerspectiveDrafts,
+	}, &movies)
+	if err != nil || len(movies) != 2 || movies[1]["title"] != "Heat (draft)" {
+		t.Errorf("Expected the draft in place of movie-2, got %v (%v)", movies, err)
+	}
+
+	_, err = client.Documents.Mutate(ctx, "test-project", "production", &sanity.MutateRequest{
+		Mutations: []sanity.Mutation{
+			{Create: sanity.Document{"_id": "movie-3", "_type": "movie", "title": "Ran"}},
+			{Patch: &sanity.Patch{Id: "movie-1", Set: map[string]any{"rating.imdb": 8.5}, Inc: map[string]float64{"year": 1}}},
+			{Delete: &sanity.DeleteMutation{Query: `*[_type == "person"]`}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	doc, err := client.Documents.Get(ctx, "test-project", "production", "movie-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if doc["year"] != 1980.0 || doc["rating"].(map[string]any)["imdb"] != 8.5 {
+		t.Errorf("Expected patched movie-1, got %v", doc)
+	}
+	if fake.Document("test-project", "production", "person-1") != nil {
+		t.Error("Expected person-1 to be deleted")
+	}
+	if fake.Document("test-project", "production", "movie-3") == nil {
+		t.Error("Expected movie-3 to be created")
+	}
+}
+
+func TestServer_MutateIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	fake.AddDocuments("test-project", "production", sanity.Document{"_id": "movie-1", "_type": "movie"})
+	client := sanity.NewClient(fake.Client())
+
+	_, err := client.Documents.Mutate(ctx, "test-project", "production", &sanity.MutateRequest{
+		Mutations: []sanity.Mutation{
+			{Create: sanity.Document{"_id": "movie-2", "_type": "movie"}},
+			{Create: sanity.Document{"_id": "movie-1", "_type": "movie"}},
+		},
+	})
+
+	var apiErr *sanity.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict || apiErr.ErrorType != "mutationError" {
+		t.Errorf("Expected conflict mutation error, got %v", err)
+	}
+	if docs := fake.Documents("test-project", "production"); len(docs) != 1 {
+		t.Errorf("Expected no documents to be created, got %v", docs)
+	}
+}
+
+func TestServer_PaginateQuery(t *testing.T) {
+	fake := NewServer()
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		fake.AddDocuments("test-project", "production", sanity.Document{"_id": id, "_type": "letter"})
+	}
+	client := sanity.NewClient(fake.Client())
+
+	p := client.Documents.PaginateQuery("test-project", "production", &sanity.PaginateQueryRequest{
+		Query:    `*[_type == "letter"]`,
+		PageSize: 2,
+	})
+	docs, err := p.All(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(docs) != 5 || docs[4].Id() != "e" {
+		t.Errorf("Expected 5 letters in order, got %v", docs)
+	}
+}
+
+func TestServer_UnsupportedQuery(t *testing.T) {
+	fake := NewServer()
+	fake.AddDataset("test-project", sanity.Dataset{Name: "production"})
+	client := sanity.NewClient(fake.Client())
+
+	var result any
+	err := client.Documents.Query(context.Background(), "test-project", "production", &sanity.QueryRequest{
+		Query: `*[_type == "movie"]{title}`,
+	}, &result)
+
+	var apiErr *sanity.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected bad request error, got %v", err)
+	}
+}